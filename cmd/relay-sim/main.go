@@ -0,0 +1,92 @@
+// Copyright © 2022 Relay Inc.
+
+// Command relay-sim runs a workflow on a locally running workflow server with a simulated
+// Relay device, without hardware or the Relay cloud. It prints what the workflow says and
+// does, and reads commands and speech from the terminal or a script:
+//
+//	go run ./cmd/relay-sim -url ws://localhost:8080/hellopath
+//	go run ./cmd/relay-sim -url ws://localhost:8080/hellopath -script hello.txt
+//
+// Type /help for the commands; any other line is spoken into the device.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"time"
+
+	"relay-go/pkg/devicesim"
+)
+
+func main() {
+	workflowUrl := flag.String("url", "ws://localhost:8080/hellopath", "the websocket URL of the workflow")
+	name := flag.String("name", "sim", "the name of the simulated device")
+	script := flag.String("script", "", "a file of commands to run instead of reading the terminal")
+	start := flag.Bool("start", true, "trigger the workflow when connected")
+	phrase := flag.String("phrase", "", "trigger the workflow by this phrase instead of the button")
+	word := flag.Duration("word", devicesim.DEFAULT_WORD_DURATION, "how long the device takes to say a word")
+	play := flag.Duration("play", devicesim.DEFAULT_PLAY_DURATION, "how long the device takes to play a file")
+	flag.Parse()
+
+	device, err := devicesim.Connect(*workflowUrl, *name, devicesim.WithPromptDurations(*word, *play))
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "relay-sim:", err)
+		os.Exit(1)
+	}
+	fmt.Printf("connected to %s as %s, type /help for commands\n", *workflowUrl, device.Uri)
+
+	if *start {
+		if err := device.Start(*phrase); err != nil {
+			fmt.Fprintln(os.Stderr, "relay-sim:", err)
+			os.Exit(1)
+		}
+	}
+
+	var input io.Reader = os.Stdin
+	if *script != "" {
+		file, err := os.Open(*script)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "relay-sim:", err)
+			os.Exit(1)
+		}
+		defer file.Close()
+		input = file
+	}
+	ran := make(chan error, 1)
+	go func() {
+		ran <- device.Run(input)
+	}()
+
+	interrupted := make(chan os.Signal, 1)
+	signal.Notify(interrupted, os.Interrupt)
+	select {
+	case err = <-ran:
+		if err == nil {
+			// the script ended, let the workflow finish
+			select {
+			case <-device.Done():
+			case <-interrupted:
+			}
+		}
+	case <-device.Done():
+	case <-interrupted:
+	}
+
+	select {
+	case <-device.Done():
+		fmt.Println("disconnected")
+	default:
+		device.Stop("normal")
+		select {
+		case <-device.Done():
+		case <-time.After(time.Second):
+		}
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "relay-sim:", err)
+		os.Exit(1)
+	}
+}
@@ -1,9 +1,11 @@
 module relay-go
 
-go 1.17
+go 1.18
 
 require (
 	github.com/gorilla/mux v1.8.0
 	github.com/gorilla/websocket v1.4.2
 	github.com/sirupsen/logrus v1.9.0
 )
+
+require golang.org/x/sys v0.0.0-20220715151400-c0bba94af5f8 // indirect
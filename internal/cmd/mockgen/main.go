@@ -0,0 +1,470 @@
+// Copyright © 2022 Relay Inc.
+
+// Command mockgen generates the methods of relaymock.Mock from the RelayApi and AsyncApi
+// interfaces of the sdk package, so the mock follows the interfaces as they change. It is run
+// by go generate in pkg/relaymock:
+//
+//	go run ../../internal/cmd/mockgen -sdk ../sdk -out relaymock_gen.go
+//
+// Every method of RelayApi gets a XxxFunc field on Mock. Methods already declared on Mock in
+// the hand-written files of the package, the files without a "Code generated" header, are not
+// generated, only their fields.
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"log"
+	"os"
+	"regexp"
+	"strings"
+)
+
+func main() {
+	sdkDir := flag.String("sdk", "../sdk", "the directory of the sdk package")
+	mockDir := flag.String("pkg", ".", "the directory of the mock's package")
+	out := flag.String("out", "relaymock_gen.go", "the file to generate")
+	flag.Parse()
+
+	src, err := generate(*sdkDir, *mockDir)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := os.WriteFile(*out, src, 0644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// Returns the source of the generated file for the mock's package in mockDir.
+func generate(sdkDir string, mockDir string) ([]byte, error) {
+	relayApi := findInterface(sdkDir, "RelayApi")
+	asyncApi := findInterface(sdkDir, "AsyncApi")
+	events := constants(sdkDir)
+	declared := declaredMethods(mockDir, "Mock")
+
+	var gen generator
+	gen.printf("// Code generated by mockgen. DO NOT EDIT.\n\n")
+	gen.printf("package relaymock\n\n")
+	gen.printf("import (\n\"context\"\n\n\"relay-go/pkg/sdk\"\n)\n\n")
+	gen.generateMock(relayApi, declared, events)
+	gen.generateAsync(asyncApi, relayApi)
+
+	src, err := format.Source(gen.buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("formatting generated code: %v\n%s", err, gen.buf.Bytes())
+	}
+	return src, nil
+}
+
+// A method of an interface, with the types qualified for use outside the sdk package.
+type method struct {
+	name    string
+	params  []param
+	results []string
+}
+
+type param struct {
+	name     string
+	typ      string
+	variadic bool
+}
+
+func (m method) signature() string {
+	return "(" + m.paramList() + ")" + resultList(m.results)
+}
+
+func (m method) paramList() string {
+	list := make([]string, len(m.params))
+	for i, p := range m.params {
+		list[i] = p.name + " " + p.typ
+	}
+	return strings.Join(list, ", ")
+}
+
+// the params as the arguments of a call, a variadic one as a slice
+func (m method) recordArgs() string {
+	list := make([]string, len(m.params))
+	for i, p := range m.params {
+		list[i] = p.name
+	}
+	return strings.Join(list, ", ")
+}
+
+// the arguments to pass the params on to a function with the same signature
+func (m method) args() string {
+	list := make([]string, len(m.params))
+	for i, p := range m.params {
+		list[i] = p.name
+		if p.variadic {
+			list[i] += "..."
+		}
+	}
+	return strings.Join(list, ", ")
+}
+
+func resultList(results []string) string {
+	switch len(results) {
+	case 0:
+		return ""
+	case 1:
+		return " " + results[0]
+	}
+	return " (" + strings.Join(results, ", ") + ")"
+}
+
+// the type of the handler, if the method registers an event handler, i.e. OnStart
+func (m method) handler() (handlerType string, ok bool) {
+	if !strings.HasPrefix(m.name, "On") || len(m.params) != 1 || len(m.results) != 0 || !strings.HasPrefix(m.params[0].typ, "func(") {
+		return "", false
+	}
+	return m.params[0].typ, true
+}
+
+type generator struct {
+	buf bytes.Buffer
+}
+
+func (gen *generator) printf(format string, args ...interface{}) {
+	fmt.Fprintf(&gen.buf, format, args...)
+}
+
+func (gen *generator) generateMock(relayApi []method, declared map[string]bool, constants map[string]bool) {
+	gen.printf("// A Mock is a fake sdk.RelayApi. Each method records its call, and returns the results of\n")
+	gen.printf("// its XxxFunc field, or zero values if the field is nil. Set the fields before the mock is\n")
+	gen.printf("// used. The zero value is ready to use.\n")
+	gen.printf("type Mock struct {\n")
+	for _, m := range relayApi {
+		gen.printf("%sFunc func%s\n", m.name, m.signature())
+	}
+	gen.printf("\nmockState\n}\n\n")
+
+	for _, m := range relayApi {
+		if declared[m.name] {
+			continue
+		}
+		gen.printf("func (mock *Mock) %s%s {\n", m.name, m.signature())
+		gen.printf("mock.record(%q%s)\n", m.name, prefixComma(m.recordArgs()))
+		if _, ok := m.handler(); ok {
+			gen.printf("mock.setHandler(%q, %s)\n", m.name, m.params[0].name)
+		}
+		gen.printf("if mock.%sFunc != nil {\n", m.name)
+		if len(m.results) > 0 {
+			gen.printf("return mock.%sFunc(%s)\n}\n", m.name, m.args())
+			names := make([]string, len(m.results))
+			for i, result := range m.results {
+				names[i] = fmt.Sprintf("r%d", i)
+				gen.printf("var %s %s\n", names[i], result)
+			}
+			gen.printf("return %s\n}\n\n", strings.Join(names, ", "))
+		} else {
+			gen.printf("mock.%sFunc(%s)\n}\n}\n\n", m.name, m.args())
+		}
+
+		if handlerType, ok := m.handler(); ok {
+			// the handler's own parameters become the parameters of the Emit method
+			handler := funcParams(handlerType)
+			emit := "Emit" + strings.TrimPrefix(m.name, "On")
+			publish := publishedEvent(handler, eventConstant(m.name), constants)
+			if publish != "" {
+				event := "its event"
+				if publish != paramNames(handler) {
+					event = eventConstant(m.name)
+				}
+				if lifecycleEvents[eventConstant(m.name)] {
+					gen.printf("// Passes the event to a pending WaitForEvent and to the handler registered with\n")
+				} else {
+					gen.printf("// Passes the event to a pending WaitForEvent, or else to the handler registered with\n")
+				}
+				gen.printf("// %s, then publishes it to the subscribers of %s.\n", m.name, event)
+				gen.printf("// Returns false if none got it.\n")
+			} else {
+				gen.printf("// Calls the handler registered with %s. Returns false if there is none.\n", m.name)
+			}
+			gen.printf("func (mock *Mock) %s(%s) bool {\n", emit, handler)
+			if publish != "" {
+				eventWrapper := publish
+				if publish != paramNames(handler) {
+					eventWrapper = "eventWrapper"
+					gen.printf("eventWrapper := %s\n", publish)
+				}
+				gen.printf("waited := mock.deliverToWaiter(%s)\n", eventWrapper)
+				gen.printf("fn, _ := mock.handler(%q).(%s)\n", m.name, handlerType)
+				if lifecycleEvents[eventConstant(m.name)] {
+					// the handlers always see START and STOP
+					gen.printf("if fn != nil {\nfn(%s)\n}\n", paramNames(handler))
+				} else {
+					gen.printf("if fn != nil && !waited {\nfn(%s)\n}\n", paramNames(handler))
+				}
+				gen.printf("return mock.publish(%s) > 0 || waited || fn != nil\n}\n\n", eventWrapper)
+			} else {
+				gen.printf("fn, _ := mock.handler(%q).(%s)\n", m.name, handlerType)
+				gen.printf("if fn == nil {\nreturn false\n}\n")
+				gen.printf("fn(%s)\nreturn true\n}\n\n", paramNames(handler))
+			}
+		}
+	}
+}
+
+// The events the OnXxx handlers get even if a WaitForEvent took them.
+var lifecycleEvents = map[string]bool{"START": true, "STOP": true}
+
+var wordStart = regexp.MustCompile(`([a-z0-9])([A-Z])`)
+
+// the name of the Event constant of the sdk for a handler method, i.e. CALL_START_REQUEST for
+// OnCallStartRequest
+func eventConstant(handlerMethod string) string {
+	name := strings.TrimPrefix(handlerMethod, "On")
+	return strings.ToUpper(wordStart.ReplaceAllString(name, "${1}_${2}"))
+}
+
+// The expression Emit passes to Publish, for a handler of an event, or "" for a handler
+// of something else, i.e. an error.
+func publishedEvent(handlerParams string, constant string, constants map[string]bool) string {
+	if strings.Contains(handlerParams, ",") {
+		return ""
+	}
+	fields := strings.Fields(handlerParams)
+	name, typ := fields[0], fields[1]
+	switch {
+	case typ == "sdk.EventWrapper":
+		return name
+	case strings.HasPrefix(typ, "sdk.") && strings.HasSuffix(typ, "Event") && constants[constant]:
+		return fmt.Sprintf("wrapEvent(sdk.%s, %s)", constant, name)
+	}
+	return ""
+}
+
+// Generates mockAsync, the AsyncApi of Mock, which runs each request with the matching Ctx
+// method of the mock.
+func (gen *generator) generateAsync(asyncApi []method, relayApi []method) {
+	byName := make(map[string]method)
+	for _, m := range relayApi {
+		byName[m.name] = m
+	}
+	gen.printf("// The AsyncApi of a Mock, its requests call the Ctx methods of the mock.\n")
+	gen.printf("type mockAsync struct {\nmock *Mock\n}\n\n")
+	for _, m := range asyncApi {
+		target, ok := byName[m.name+"Ctx"]
+		if !ok {
+			// i.e. ListenSpeech, which takes a context without the Ctx suffix
+			target = byName[m.name]
+		}
+		if len(target.params) == 0 || target.params[0].typ != "context.Context" || len(target.results) != 2 ||
+			len(m.params) != len(target.params) {
+			log.Fatalf("no Ctx method of RelayApi for AsyncApi.%s", m.name)
+		}
+		gen.printf("func (async mockAsync) %s%s {\n", m.name, m.signature())
+		gen.printf("return sdk.NewFuture(func() (%s, %s) {\n", target.results[0], target.results[1])
+		gen.printf("return async.mock.%s(%s)\n})\n}\n\n", target.name, m.args())
+	}
+}
+
+func prefixComma(list string) string {
+	if list == "" {
+		return ""
+	}
+	return ", " + list
+}
+
+// the parameter list of a func type, i.e. "startEvent sdk.StartEvent" of
+// "func(startEvent sdk.StartEvent)"
+func funcParams(funcType string) string {
+	return strings.TrimSuffix(strings.TrimPrefix(funcType, "func("), ")")
+}
+
+func paramNames(params string) string {
+	var names []string
+	for _, p := range strings.Split(params, ", ") {
+		names = append(names, strings.Fields(p)[0])
+	}
+	return strings.Join(names, ", ")
+}
+
+// finds the interface in the package in dir, and returns its methods
+func findInterface(dir string, name string) []method {
+	fset := token.NewFileSet()
+	pkgs, err := parser.ParseDir(fset, dir, nil, 0)
+	if err != nil {
+		log.Fatal(err)
+	}
+	for _, pkg := range pkgs {
+		for _, file := range pkg.Files {
+			for _, decl := range file.Decls {
+				gen, ok := decl.(*ast.GenDecl)
+				if !ok || gen.Tok != token.TYPE {
+					continue
+				}
+				for _, spec := range gen.Specs {
+					typeSpec := spec.(*ast.TypeSpec)
+					if iface, ok := typeSpec.Type.(*ast.InterfaceType); ok && typeSpec.Name.Name == name {
+						return interfaceMethods(iface)
+					}
+				}
+			}
+		}
+	}
+	log.Fatalf("no interface %s in %s", name, dir)
+	return nil
+}
+
+func interfaceMethods(iface *ast.InterfaceType) []method {
+	var methods []method
+	for _, field := range iface.Methods.List {
+		funcType, ok := field.Type.(*ast.FuncType)
+		if !ok {
+			log.Fatalf("embedded interfaces are not supported")
+		}
+		m := method{name: field.Names[0].Name}
+		for i, p := range expandFields(funcType.Params) {
+			if p.name == "" || p.name == "_" {
+				p.name = fmt.Sprintf("arg%d", i)
+			}
+			m.params = append(m.params, p)
+		}
+		for _, r := range expandFields(funcType.Results) {
+			m.results = append(m.results, r.typ)
+		}
+		methods = append(methods, m)
+	}
+	return methods
+}
+
+func expandFields(fields *ast.FieldList) []param {
+	if fields == nil {
+		return nil
+	}
+	var params []param
+	for _, field := range fields.List {
+		p := param{typ: typeString(field.Type)}
+		_, p.variadic = field.Type.(*ast.Ellipsis)
+		if len(field.Names) == 0 {
+			params = append(params, p)
+		}
+		for _, name := range field.Names {
+			p.name = name.Name
+			params = append(params, p)
+		}
+	}
+	return params
+}
+
+// prints a type of the sdk package as it is written outside of it
+func typeString(expr ast.Expr) string {
+	switch t := expr.(type) {
+	case *ast.Ident:
+		if ast.IsExported(t.Name) {
+			return "sdk." + t.Name
+		}
+		return t.Name
+	case *ast.SelectorExpr:
+		return typeString(t.X) + "." + t.Sel.Name
+	case *ast.StarExpr:
+		return "*" + typeString(t.X)
+	case *ast.ArrayType:
+		if t.Len == nil {
+			return "[]" + typeString(t.Elt)
+		}
+	case *ast.MapType:
+		return "map[" + typeString(t.Key) + "]" + typeString(t.Value)
+	case *ast.Ellipsis:
+		return "..." + typeString(t.Elt)
+	case *ast.IndexExpr:
+		return typeString(t.X) + "[" + typeString(t.Index) + "]"
+	case *ast.InterfaceType:
+		if len(t.Methods.List) == 0 {
+			return "interface{}"
+		}
+	case *ast.FuncType:
+		var params []string
+		for _, p := range expandFields(t.Params) {
+			params = append(params, strings.TrimSpace(p.name+" "+p.typ))
+		}
+		var results []string
+		for _, r := range expandFields(t.Results) {
+			results = append(results, r.typ)
+		}
+		return "func(" + strings.Join(params, ", ") + ")" + resultList(results)
+	}
+	log.Fatalf("unsupported type %T", expr)
+	return ""
+}
+
+var generatedHeader = regexp.MustCompile(`^// Code generated .* DO NOT EDIT\.$`)
+
+// tells whether the file has a "Code generated" header before its package clause
+func isGenerated(file *ast.File) bool {
+	for _, group := range file.Comments {
+		if group.Pos() > file.Package {
+			break
+		}
+		for _, comment := range group.List {
+			if generatedHeader.MatchString(comment.Text) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// the names of the constants of the package in dir
+func constants(dir string) map[string]bool {
+	fset := token.NewFileSet()
+	pkgs, err := parser.ParseDir(fset, dir, nil, 0)
+	if err != nil {
+		log.Fatal(err)
+	}
+	names := make(map[string]bool)
+	for _, pkg := range pkgs {
+		for _, file := range pkg.Files {
+			for _, decl := range file.Decls {
+				if gen, ok := decl.(*ast.GenDecl); ok && gen.Tok == token.CONST {
+					for _, spec := range gen.Specs {
+						for _, name := range spec.(*ast.ValueSpec).Names {
+							names[name.Name] = true
+						}
+					}
+				}
+			}
+		}
+	}
+	return names
+}
+
+// the methods of the type declared in the hand-written files of the package in dir
+func declaredMethods(dir string, typeName string) map[string]bool {
+	fset := token.NewFileSet()
+	skipTests := func(info os.FileInfo) bool {
+		return !strings.HasSuffix(info.Name(), "_test.go")
+	}
+	pkgs, err := parser.ParseDir(fset, dir, skipTests, parser.ParseComments)
+	if err != nil {
+		log.Fatal(err)
+	}
+	declared := make(map[string]bool)
+	for _, pkg := range pkgs {
+		for _, file := range pkg.Files {
+			if isGenerated(file) {
+				continue
+			}
+			for _, decl := range file.Decls {
+				fn, ok := decl.(*ast.FuncDecl)
+				if !ok || fn.Recv == nil || len(fn.Recv.List) != 1 {
+					continue
+				}
+				recv := fn.Recv.List[0].Type
+				if star, ok := recv.(*ast.StarExpr); ok {
+					recv = star.X
+				}
+				if ident, ok := recv.(*ast.Ident); ok && ident.Name == typeName {
+					declared[fn.Name.Name] = true
+				}
+			}
+		}
+	}
+	return declared
+}
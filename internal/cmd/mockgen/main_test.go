@@ -0,0 +1,39 @@
+// Copyright © 2022 Relay Inc.
+
+package main
+
+import (
+	"bytes"
+	"os"
+	"testing"
+)
+
+// Regenerating the mock must reproduce the checked-in file, so it is not stale and the
+// hand-written methods of the package are not generated a second time.
+func TestGeneratedMockIsUpToDate(t *testing.T) {
+	const mockDir = "../../../pkg/relaymock"
+	want, err := os.ReadFile(mockDir + "/relaymock_gen.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := generate("../../../pkg/sdk", mockDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Error("relaymock_gen.go is out of date, run go generate ./pkg/relaymock")
+	}
+}
+
+func TestEventConstant(t *testing.T) {
+	for method, want := range map[string]string{
+		"OnButton":               "BUTTON",
+		"OnTimerFired":           "TIMER_FIRED",
+		"OnCallStartRequest":     "CALL_START_REQUEST",
+		"OnInteractionLifecycle": "INTERACTION_LIFECYCLE",
+	} {
+		if got := eventConstant(method); got != want {
+			t.Errorf("eventConstant(%q) = %q, want %q", method, got, want)
+		}
+	}
+}
@@ -0,0 +1,664 @@
+// Copyright © 2022 Relay Inc.
+
+// Command protogen generates the event, request and response types of the sdk package, the
+// _type of each request, a method sending each request, and the api methods that only send a
+// request and return its response, from the description of the wire protocol in
+// protocol.json. It is run by go generate in pkg/sdk:
+//
+//	go run ../../internal/cmd/protogen -schema protocol.json -out protocol_gen.go -test protocol_gen_test.go
+//
+// The -test file lists a value of each generated type, and the method sending each request,
+// by the _type of its message, for the tests that check the generated code against the schema.
+//
+// The schema is checked before anything is generated, i.e. that the json names of a message
+// are unique and well formed, and that the params of a method map to fields of its request.
+//
+// The schema has a list of events and a list of requests. An event has the name in its
+// _type, wf_api_<name>_event, the Go type, and its fields. A request has the name in its
+// _type, wf_api_<name>_request, the Go type, whether it is sent to a _target, its fields,
+// its response and its methods. A request answered with something else than its response,
+// i.e. the listen request with a speech event, names the Go type of its reply, and the
+// field with the seconds the server may take to answer it. A request that starts a prompt on
+// the device, i.e. say or play, is marked prompt. A field has the Go name and type, the json
+// name, whether it is omitted when empty, and whether it is set to the _id of the request.
+// An event or response whose format is not documented is marked raw instead of listing
+// fields; its struct keeps all the fields of the message in a map. A method has a name and a
+// doc comment, its params, each setting a field of the request, and constant string values
+// for the other fields, and an optional debug message, in which {param} is replaced by the
+// value of the param.
+//
+// Each request gets an unexported method named after its Go type, i.e. sayCtx for
+// sayRequest, that takes a param for each of its fields but _type and _id, sends it, and
+// returns its response or reply. A request without a response, i.e. terminate, is only sent,
+// by a method without the Ctx suffix. A prompt request also gets an AndWaitCtx method, i.e.
+// sayAndWaitCtx, that returns once the prompt finished playing. The api methods of the schema
+// call these methods, and so do the api methods of the sdk package that are written by hand
+// because they default or convert a param or the response, i.e. Say, GetVar or the device
+// info methods, or make several requests, i.e. the Targets variants.
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"go/format"
+	"go/token"
+	"log"
+	"os"
+	"regexp"
+	"strings"
+)
+
+type schema struct {
+	Events   []message `json:"events"`
+	Requests []request `json:"requests"`
+}
+
+// an event or a response
+type message struct {
+	Name   string  `json:"name"`
+	Type   string  `json:"type"`
+	Fields []field `json:"fields"`
+	Raw    bool    `json:"raw"`
+}
+
+type request struct {
+	Name     string   `json:"name"`
+	Type     string   `json:"type"`
+	Target   bool     `json:"target"`
+	Fields   []field  `json:"fields"`
+	Response *message `json:"response"`
+	Reply    string   `json:"reply"`
+	Timeout  string   `json:"timeout"`
+	Prompt   bool     `json:"prompt"`
+	Methods  []method `json:"methods"`
+}
+
+type field struct {
+	Name      string `json:"name"`
+	Type      string `json:"type"`
+	Json      string `json:"json"`
+	Omitempty bool   `json:"omitempty"`
+	Id        bool   `json:"id"`
+	Comment   string `json:"comment"`
+}
+
+type method struct {
+	Name   string            `json:"name"`
+	Doc    string            `json:"doc"`
+	Params []param           `json:"params"`
+	Values map[string]string `json:"values"`
+	Debug  string            `json:"debug"`
+}
+
+type param struct {
+	Name  string `json:"name"`
+	Field string `json:"field"`
+}
+
+// the fields every request has, before its own
+var requestFields = []field{
+	{Name: "Type", Type: "string", Json: "_type"},
+	{Name: "Id", Type: "string", Json: "_id"},
+}
+
+var targetField = field{Name: "Target", Type: "map[string][]string", Json: "_target"}
+
+func main() {
+	schemaFile := flag.String("schema", "protocol.json", "the description of the wire protocol")
+	out := flag.String("out", "protocol_gen.go", "the file to generate, in the sdk package")
+	testOut := flag.String("test", "", "the test file listing the generated types, if any")
+	flag.Parse()
+
+	protocol, err := readSchema(*schemaFile)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if errs := protocol.check(); len(errs) > 0 {
+		for _, err := range errs {
+			log.Print(err)
+		}
+		log.Fatalf("%s: %d errors", *schemaFile, len(errs))
+	}
+
+	var gen generator
+	gen.printf("// Code generated by protogen from %s. DO NOT EDIT.\n\n", *schemaFile)
+	gen.printf("package sdk\n\n")
+	if protocol.hasRaw() {
+		gen.printf("import (\n\"context\"\n\"encoding/json\"\n)\n\n")
+	} else {
+		gen.printf("import \"context\"\n\n")
+	}
+	gen.generate(protocol)
+	gen.write(*out)
+
+	if *testOut != "" {
+		var gen generator
+		gen.printf("// Code generated by protogen from %s. DO NOT EDIT.\n\n", *schemaFile)
+		gen.printf("package sdk\n\n")
+		gen.generateTypeList(protocol)
+		gen.write(*testOut)
+	}
+}
+
+func readSchema(path string) (*schema, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	decoder := json.NewDecoder(file)
+	// a misspelled key would otherwise be ignored
+	decoder.DisallowUnknownFields()
+	protocol := &schema{}
+	if err := decoder.Decode(protocol); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return protocol, nil
+}
+
+// the fields of the request as they are sent, including _type, _id and _target
+func (req request) allFields() []field {
+	fields := append([]field(nil), requestFields...)
+	if req.Target {
+		fields = append(fields, targetField)
+	}
+	return append(fields, req.Fields...)
+}
+
+func (protocol *schema) hasRaw() bool {
+	for _, event := range protocol.Events {
+		if event.Raw {
+			return true
+		}
+	}
+	for _, req := range protocol.Requests {
+		if req.Response != nil && req.Response.Raw {
+			return true
+		}
+	}
+	return false
+}
+
+// the name of the constant holding the _type of the request
+func (req request) typeConst() string {
+	return req.Type + "Type"
+}
+
+// the name of the method sending the request, i.e. sayCtx for sayRequest
+func (req request) sendMethod() string {
+	name := strings.TrimSuffix(req.Type, "Request")
+	if req.Response == nil {
+		return name
+	}
+	return name + "Ctx"
+}
+
+// the Go type the request is answered with
+func (req request) replyType() string {
+	if req.Reply != "" {
+		return req.Reply
+	}
+	return req.Response.Type
+}
+
+// the fields of the request set by the params of its send method, all but _type, _id and the
+// fields set to the _id
+func (req request) paramFields() []field {
+	var fields []field
+	for _, f := range req.allFields()[len(requestFields):] {
+		if !f.Id {
+			fields = append(fields, f)
+		}
+	}
+	return fields
+}
+
+// the name of the param of the send method setting the field, i.e. altLang for AltLang
+func (f field) param() string {
+	return strings.ToLower(f.Name[:1]) + f.Name[1:]
+}
+
+func (req request) wireType() string {
+	return "wf_api_" + req.Name + "_request"
+}
+
+func (req request) responseWireType() string {
+	return "wf_api_" + req.Name + "_response"
+}
+
+func (f field) tag() string {
+	if f.Json == "" {
+		return ""
+	}
+	name := f.Json
+	if f.Omitempty {
+		name += ",omitempty"
+	}
+	return fmt.Sprintf("`json:%q`", name)
+}
+
+var (
+	wireName     = regexp.MustCompile(`^[a-z][a-z0-9_]*$`)
+	jsonName     = regexp.MustCompile(`^[A-Za-z0-9_]+$`)
+	placeholders = regexp.MustCompile(`\{([^}]*)\}`)
+)
+
+// Returns the problems of the schema, or nothing if code can be generated from it.
+func (protocol *schema) check() []error {
+	var errs []error
+	errorf := func(format string, args ...interface{}) {
+		errs = append(errs, fmt.Errorf(format, args...))
+	}
+	types := make(map[string]bool)
+	checkType := func(name string, exported bool) {
+		if !token.IsIdentifier(name) || token.IsExported(name) != exported {
+			errorf("%s: not a valid name for the type", name)
+		} else if types[name] {
+			errorf("%s: declared twice", name)
+		}
+		types[name] = true
+	}
+
+	events := make(map[string]bool)
+	for _, event := range protocol.Events {
+		checkType(event.Type, true)
+		if !wireName.MatchString(event.Name) || events[event.Name] {
+			errorf("%s: invalid or duplicate event name %q", event.Type, event.Name)
+		}
+		events[event.Name] = true
+		errs = append(errs, checkMessage(event)...)
+	}
+
+	requests := make(map[string]bool)
+	methods := make(map[string]bool)
+	for _, req := range protocol.Requests {
+		checkType(req.Type, false)
+		if !wireName.MatchString(req.Name) || requests[req.Name] {
+			errorf("%s: invalid or duplicate request name %q", req.Type, req.Name)
+		}
+		requests[req.Name] = true
+		errs = append(errs, checkFields(req.Type, req.allFields())...)
+		errs = append(errs, checkRequest(req)...)
+		if req.Response != nil {
+			checkType(req.Response.Type, true)
+			errs = append(errs, checkMessage(*req.Response)...)
+		}
+		for _, m := range req.Methods {
+			if !token.IsIdentifier(m.Name) || !token.IsExported(m.Name) || methods[m.Name] {
+				errorf("%s: invalid or duplicate method name %q", req.Type, m.Name)
+			}
+			methods[m.Name] = true
+			errs = append(errs, checkMethod(req, m)...)
+		}
+	}
+	return errs
+}
+
+// the local variables of the send methods, which no param may be named after
+var sendLocals = map[string]bool{"ctx": true, "id": true, "req": true, "res": true, "err": true}
+
+func checkRequest(req request) []error {
+	var errs []error
+	errorf := func(format string, args ...interface{}) {
+		errs = append(errs, fmt.Errorf("%s: "+format, append([]interface{}{req.Type}, args...)...))
+	}
+	if !strings.HasSuffix(req.Type, "Request") || req.Type == "Request" {
+		errorf("the type of a request must end in Request")
+	}
+	if req.Response == nil && (len(req.Methods) > 0 || req.Reply != "" || req.Timeout != "" || req.Prompt) {
+		errorf("methods, reply, timeout or prompt of a request without a response")
+	}
+	if req.Reply != "" && !token.IsIdentifier(req.Reply) {
+		errorf("invalid reply type %q", req.Reply)
+	}
+	for _, f := range req.Fields {
+		if f.Id && f.Type != "string" {
+			errorf("%s: set to the _id, but not a string", f.Name)
+		}
+		if !f.Id && (token.IsKeyword(f.param()) || sendLocals[f.param()]) {
+			errorf("%s: the param %s is a keyword or a local variable", f.Name, f.param())
+		}
+	}
+	if req.Timeout != "" {
+		found := false
+		for _, f := range req.paramFields() {
+			found = found || (f.Name == req.Timeout && f.Type == "int")
+		}
+		if !found {
+			errorf("the timeout %q is not an int field set by a param", req.Timeout)
+		}
+	}
+	return errs
+}
+
+func checkMessage(msg message) []error {
+	if msg.Raw && len(msg.Fields) > 0 {
+		return []error{fmt.Errorf("%s: fields of a raw message", msg.Type)}
+	}
+	return checkFields(msg.Type, msg.Fields)
+}
+
+func checkFields(typeName string, fields []field) []error {
+	var errs []error
+	names := make(map[string]bool)
+	jsonNames := make(map[string]bool)
+	for _, f := range fields {
+		if !token.IsIdentifier(f.Name) || !token.IsExported(f.Name) || names[f.Name] {
+			errs = append(errs, fmt.Errorf("%s: invalid or duplicate field name %q", typeName, f.Name))
+		}
+		names[f.Name] = true
+		if f.Type == "" {
+			errs = append(errs, fmt.Errorf("%s.%s: missing type", typeName, f.Name))
+		}
+		if f.Json == "" {
+			if f.Omitempty {
+				errs = append(errs, fmt.Errorf("%s.%s: omitempty without a json name", typeName, f.Name))
+			}
+			continue
+		}
+		if !jsonName.MatchString(f.Json) || jsonNames[f.Json] {
+			errs = append(errs, fmt.Errorf("%s.%s: invalid or duplicate json name %q", typeName, f.Name, f.Json))
+		}
+		jsonNames[f.Json] = true
+	}
+	return errs
+}
+
+func checkMethod(req request, m method) []error {
+	var errs []error
+	errorf := func(format string, args ...interface{}) {
+		errs = append(errs, fmt.Errorf("%s: "+format, append([]interface{}{m.Name}, args...)...))
+	}
+	if m.Doc == "" {
+		errorf("missing doc")
+	}
+	fields := make(map[string]field)
+	for _, f := range req.allFields() {
+		fields[f.Name] = f
+	}
+	set := map[string]bool{"Type": true, "Id": true}
+	params := make(map[string]bool)
+	for _, p := range m.Params {
+		if !token.IsIdentifier(p.Name) || token.IsExported(p.Name) || p.Name == "ctx" || params[p.Name] {
+			errorf("invalid or duplicate param name %q", p.Name)
+		}
+		params[p.Name] = true
+		if _, ok := fields[p.Field]; !ok || set[p.Field] {
+			errorf("param %s sets unknown or already set field %q", p.Name, p.Field)
+		}
+		set[p.Field] = true
+	}
+	for name := range m.Values {
+		if f, ok := fields[name]; !ok || set[name] || f.Type != "string" {
+			errorf("value for unknown, already set or non string field %q", name)
+		}
+		set[name] = true
+	}
+	for _, f := range req.allFields() {
+		if f.Id && set[f.Name] {
+			errorf("param or value for the field %s, which is set to the _id", f.Name)
+		} else if !f.Id && !set[f.Name] {
+			errorf("no param or value for the field %s", f.Name)
+		}
+	}
+	for _, match := range placeholders.FindAllStringSubmatch(m.Debug, -1) {
+		if !params[match[1]] {
+			errorf("debug message refers to unknown param %q", match[1])
+		}
+	}
+	return errs
+}
+
+type generator struct {
+	buf bytes.Buffer
+}
+
+func (gen *generator) printf(format string, args ...interface{}) {
+	fmt.Fprintf(&gen.buf, format, args...)
+}
+
+// formats the generated code and writes it to the file
+func (gen *generator) write(path string) {
+	src, err := format.Source(gen.buf.Bytes())
+	if err != nil {
+		log.Fatalf("formatting generated code: %v\n%s", err, gen.buf.Bytes())
+	}
+	if err := os.WriteFile(path, src, 0644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func (gen *generator) generate(protocol *schema) {
+	gen.printf("// The _type of each request.\n")
+	gen.printf("const (\n")
+	for _, req := range protocol.Requests {
+		gen.printf("%s = %q\n", req.typeConst(), req.wireType())
+	}
+	gen.printf(")\n\n")
+
+	gen.printf("// EVENTS\n\n")
+	for _, event := range protocol.Events {
+		gen.printf("// The wf_api_%s_event.\n", event.Name)
+		gen.generateMessage(event)
+	}
+
+	gen.printf("// REQUEST/RESPONSE\n\n")
+	for _, req := range protocol.Requests {
+		gen.printf("// A %s.\n", req.wireType())
+		gen.generateStruct(req.Type, req.allFields())
+		if req.Response != nil {
+			gen.printf("// The response to a %s.\n", req.wireType())
+			gen.generateMessage(*req.Response)
+		}
+	}
+
+	gen.printf("// REQUESTS\n\n")
+	for _, req := range protocol.Requests {
+		gen.generateSend(req)
+	}
+
+	gen.printf("// API functions\n\n")
+	for _, req := range protocol.Requests {
+		for _, m := range req.Methods {
+			gen.generateMethod(req, m)
+		}
+	}
+}
+
+// Generates the struct of an event or response. The struct of a raw message keeps all of its
+// fields in a map, which it is decoded from and encoded to.
+func (gen *generator) generateMessage(msg message) {
+	if !msg.Raw {
+		gen.generateStruct(msg.Type, msg.Fields)
+		return
+	}
+	gen.printf("type %s struct {\n", msg.Type)
+	gen.printf("Fields map[string]interface{} `json:\"-\"` // all the fields of the message, including _type\n")
+	gen.printf("}\n\n")
+	gen.printf("func (msg *%s) UnmarshalJSON(data []byte) error {\n", msg.Type)
+	gen.printf("msg.Fields = nil\n")
+	gen.printf("return json.Unmarshal(data, &msg.Fields)\n}\n\n")
+	gen.printf("func (msg %s) MarshalJSON() ([]byte, error) {\n", msg.Type)
+	gen.printf("if msg.Fields == nil {\nreturn []byte(\"{}\"), nil\n}\n")
+	gen.printf("return json.Marshal(msg.Fields)\n}\n\n")
+}
+
+// Generates the list of the generated types, by the _type of their message.
+func (gen *generator) generateTypeList(protocol *schema) {
+	gen.printf("// A value of each type generated from the schema, by the _type of its message.\n")
+	gen.printf("var protocolTypes = map[string]interface{}{\n")
+	for _, event := range protocol.Events {
+		gen.printf("%q: %s{},\n", "wf_api_"+event.Name+"_event", event.Type)
+	}
+	for _, req := range protocol.Requests {
+		gen.printf("%q: %s{},\n", req.wireType(), req.Type)
+		if req.Response != nil {
+			gen.printf("%q: %s{},\n", req.responseWireType(), req.Response.Type)
+		}
+	}
+	gen.printf("}\n\n")
+
+	gen.printf("// The method sending each request, by its _type.\n")
+	gen.printf("var protocolRequests = map[string]interface{}{\n")
+	for _, req := range protocol.Requests {
+		gen.printf("%q: (*workflowInstance).%s,\n", req.wireType(), req.sendMethod())
+	}
+	gen.printf("}\n")
+}
+
+func (gen *generator) generateStruct(name string, fields []field) {
+	if len(fields) == 0 {
+		gen.printf("type %s struct{}\n\n", name)
+		return
+	}
+	gen.printf("type %s struct {\n", name)
+	for _, f := range fields {
+		gen.printf("%s %s %s", f.Name, f.Type, f.tag())
+		if f.Comment != "" {
+			gen.printf(" // %s", f.Comment)
+		}
+		gen.printf("\n")
+	}
+	gen.printf("}\n\n")
+}
+
+// Generates the method, which makes the request with the context of the workflow, and its
+// Ctx variant.
+func (gen *generator) generateMethod(req request, m method) {
+	fields := make(map[string]field)
+	for _, f := range req.allFields() {
+		fields[f.Name] = f
+	}
+	var params, args []string
+	for _, p := range m.Params {
+		typ := fields[p.Field].Type
+		if p.Field == targetField.Name {
+			typ = "string"
+		}
+		params = append(params, p.Name+" "+typ)
+		args = append(args, p.Name)
+	}
+	paramList := strings.Join(params, ", ")
+	res := req.replyType()
+
+	gen.printf("%s", comment(m.Doc))
+	gen.printf("func (wfInst *workflowInstance) %s(%s) %s {\n", m.Name, paramList, res)
+	gen.printf("res, _ := wfInst.%sCtx(%s)\n", m.Name, strings.Join(append([]string{"wfInst.Ctx"}, args...), ", "))
+	gen.printf("return res\n}\n\n")
+
+	gen.printf("// Same as %s, but bounded by ctx. Returns an error if the request fails.\n", m.Name)
+	gen.printf("func (wfInst *workflowInstance) %sCtx(%s) (%s, error) {\n", m.Name, strings.Join(append([]string{"ctx context.Context"}, params...), ", "), res)
+	if m.Debug != "" {
+		gen.printf("wfInst.Logger.Debug(%s)\n", debugArgs(m.Debug))
+	}
+
+	// the args of the send method, in the order of the fields of the request
+	values := make(map[string]string)
+	for _, p := range m.Params {
+		values[p.Field] = p.Name
+		if p.Field == targetField.Name {
+			values[p.Field] = "makeTargetMap(" + p.Name + ")"
+		}
+	}
+	for name, value := range m.Values {
+		values[name] = fmt.Sprintf("%q", value)
+	}
+	sendArgs := []string{"ctx"}
+	for _, f := range req.paramFields() {
+		sendArgs = append(sendArgs, values[f.Name])
+	}
+	gen.printf("return wfInst.%s(%s)\n}\n\n", req.sendMethod(), strings.Join(sendArgs, ", "))
+}
+
+// Generates the method sending the request, and returning its response unless it has none,
+// and the AndWaitCtx variant of a prompt request.
+func (gen *generator) generateSend(req request) {
+	var params, elements []string
+	for _, f := range req.allFields() {
+		switch {
+		case f.Name == "Type":
+			elements = append(elements, "Type: "+req.typeConst())
+		case f.Name == "Id" || f.Id:
+			elements = append(elements, f.Name+": id")
+		default:
+			params = append(params, f.param()+" "+f.Type)
+			elements = append(elements, f.Name+": "+f.param())
+		}
+	}
+	build := fmt.Sprintf("id := wfInst.makeId()\nreq := %s{%s}\n", req.Type, strings.Join(elements, ", "))
+
+	if req.Response == nil {
+		gen.printf("// Sends a %s, which is not answered.\n", req.wireType())
+		gen.printf("func (wfInst *workflowInstance) %s(%s) {\n", req.sendMethod(), strings.Join(params, ", "))
+		gen.printf("%swfInst.sendRequest(req)\n}\n\n", build)
+		return
+	}
+
+	params = append([]string{"ctx context.Context"}, params...)
+	send := func(name string, call string) {
+		gen.printf("func (wfInst *workflowInstance) %s(%s) (%s, error) {\n", name, strings.Join(params, ", "), req.replyType())
+		gen.printf("%sres := %s{}\n", build, req.replyType())
+		gen.printf("err := wfInst.%s\n", call)
+		gen.printf("return res, err\n}\n\n")
+	}
+	if req.Reply != "" {
+		gen.printf("// Sends a %s and returns the %s it is answered with.\n", req.wireType(), req.Reply)
+	} else {
+		gen.printf("// Sends a %s and returns its response.\n", req.wireType())
+	}
+	if req.Timeout != "" {
+		timeout := ""
+		for _, f := range req.paramFields() {
+			if f.Name == req.Timeout {
+				timeout = f.param()
+			}
+		}
+		gen.printf("// Waits for it as long as the server may take, given the %s.\n", timeout)
+		send(req.sendMethod(), fmt.Sprintf("requestTimeout(ctx, req, id, &res, responseTimeout(%s))", timeout))
+	} else {
+		send(req.sendMethod(), "request(ctx, req, id, &res)")
+	}
+	if req.Prompt {
+		name := strings.TrimSuffix(req.sendMethod(), "Ctx") + "AndWaitCtx"
+		gen.printf("// Same as %s, but also waits until the prompt started by the request finished playing.\n", req.sendMethod())
+		send(name, "requestAndWait(ctx, req, id, &res)")
+	}
+}
+
+// the arguments of Logger.Debug for the message, i.e. "placing call to ", targetUri for
+// "placing call to {targetUri}"
+func debugArgs(msg string) string {
+	var args []string
+	for {
+		loc := placeholders.FindStringSubmatchIndex(msg)
+		if loc == nil {
+			break
+		}
+		if loc[0] > 0 {
+			args = append(args, fmt.Sprintf("%q", msg[:loc[0]]))
+		}
+		args = append(args, msg[loc[2]:loc[3]])
+		msg = msg[loc[1]:]
+	}
+	if msg != "" {
+		args = append(args, fmt.Sprintf("%q", msg))
+	}
+	return strings.Join(args, ", ")
+}
+
+// the doc as a comment, wrapped at 100 columns
+func comment(doc string) string {
+	var b strings.Builder
+	line := "//"
+	for _, word := range strings.Fields(doc) {
+		if len(line)+1+len(word) > 100 && line != "//" {
+			b.WriteString(line + "\n")
+			line = "//"
+		}
+		line += " " + word
+	}
+	b.WriteString(line + "\n")
+	return b.String()
+}
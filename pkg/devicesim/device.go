@@ -0,0 +1,322 @@
+// Copyright © 2022 Relay Inc.
+
+// Package devicesim simulates a Relay device, so a workflow can be run end to end on a local
+// workflow server without hardware or the Relay cloud.
+//
+// A Device connects to a workflow as the Relay server does, and plays both the server and the
+// device: it prints what the workflow says and plays, answers listens with the text typed at
+// the terminal or given to Speak, and sends the button, lifecycle and prompt events a real
+// device would. The relay-sim command runs a Device from the terminal or from a script.
+package devicesim
+
+import (
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"relay-go/pkg/relaytest"
+	"relay-go/pkg/sdk"
+)
+
+// How long a simulated device takes to say a word, and to play a file.
+const (
+	DEFAULT_WORD_DURATION = 300 * time.Millisecond
+	DEFAULT_PLAY_DURATION = 2 * time.Second
+)
+
+// An Option configures a Device.
+type Option func(*Device)
+
+// Sets where the device prints what the workflow says and does, os.Stdout by default.
+func WithOutput(out io.Writer) Option {
+	return func(device *Device) {
+		device.out = out
+	}
+}
+
+// Sets how long the device takes to say a word and to play a file, which is when it sends
+// the prompt stopped event that SayAndWait and PlayAndWait wait for.
+func WithPromptDurations(word time.Duration, play time.Duration) Option {
+	return func(device *Device) {
+		device.wordDuration = word
+		device.playDuration = play
+	}
+}
+
+// A Device is a simulated Relay device connected to a workflow. All methods are safe for
+// concurrent use.
+type Device struct {
+	Name    string
+	Uri     string // the device URN
+	Session *relaytest.Session
+
+	out          io.Writer
+	wordDuration time.Duration
+	playDuration time.Duration
+
+	mutex        sync.Mutex
+	interactions []interaction       // the started interactions, newest last
+	listens      []relaytest.Request // listens waiting for the user to speak
+	speech       []string            // text spoken while no listen was waiting
+}
+
+type interaction struct {
+	name string
+	uri  string
+}
+
+// Connects a device with the given name to the workflow's websocket URL, i.e.
+// ws://localhost:8080/helloworld, which starts an instance of the workflow.
+func Connect(workflowUrl string, name string, opts ...Option) (*Device, error) {
+	session, err := relaytest.Dial(workflowUrl)
+	if err != nil {
+		return nil, err
+	}
+	return New(session, name, opts...), nil
+}
+
+// Simulates a device with the given name on an existing session, i.e. one dialed with
+// relaytest.Server.Dial.
+func New(session *relaytest.Session, name string, opts ...Option) *Device {
+	device := &Device{
+		Name:         name,
+		Uri:          sdk.DeviceName(name),
+		Session:      session,
+		out:          os.Stdout,
+		wordDuration: DEFAULT_WORD_DURATION,
+		playDuration: DEFAULT_PLAY_DURATION,
+	}
+	for _, opt := range opts {
+		opt(device)
+	}
+	session.Respond("say", device.say)
+	session.Respond("play", device.play)
+	session.Respond("listen", device.listen)
+	session.Respond("start_interaction", device.startInteraction)
+	session.Respond("end_interaction", device.endInteraction)
+	session.Respond("get_device_info", device.getDeviceInfo)
+	session.Respond("vibrate", device.printRequest("vibrates", "pattern"))
+	session.Respond("set_led", device.printRequest("sets its LEDs to", "effect"))
+	session.Respond("notification", device.printRequest("gets a notification", "text"))
+	session.Respond("terminate", device.terminate)
+	return device
+}
+
+// Triggers the workflow, as if the user spoke the phrase, or pressed the button if phrase is
+// empty.
+func (device *Device) Start(phrase string) error {
+	trigger := map[string]interface{}{
+		"type": sdk.BUTTON_TRIGGER,
+		"args": map[string]interface{}{"source_uri": device.Uri},
+	}
+	if phrase != "" {
+		trigger["type"] = sdk.PHRASE_TRIGGER
+		trigger["args"] = map[string]interface{}{"source_uri": device.Uri, "phrase": phrase}
+	}
+	device.printf("triggers the workflow")
+	return device.Session.SendEvent(sdk.START, map[string]interface{}{"trigger": trigger})
+}
+
+// Speaks text into the device while holding the talk button. It answers the oldest listen
+// that is waiting, or the next listen if none is.
+func (device *Device) Speak(text string) error {
+	device.mutex.Lock()
+	if len(device.listens) == 0 {
+		device.speech = append(device.speech, text)
+		device.mutex.Unlock()
+		return nil
+	}
+	req := device.listens[0]
+	device.listens = device.listens[1:]
+	device.mutex.Unlock()
+	return device.Session.Send(device.speechEvent(req, text))
+}
+
+// Taps the action button, taps times, i.e. "single" or "double". The button event comes from
+// the newest interaction, or from the device if no interaction is running.
+func (device *Device) Tap(taps string) error {
+	device.printf("taps the button (%s)", taps)
+	return device.Session.SendButton(device.sourceUri(), "action", taps)
+}
+
+// Sends an interaction lifecycle event, i.e. "suspended" or "resumed", for the newest
+// interaction.
+func (device *Device) Lifecycle(lifecycleType string) error {
+	return device.Session.SendLifecycle(device.sourceUri(), lifecycleType)
+}
+
+// Stops the workflow with the reason, i.e. "normal", and disconnects.
+func (device *Device) Stop(reason string) error {
+	device.printf("stops the workflow (%s)", reason)
+	return device.Session.SendStop(reason)
+}
+
+// Returns a channel that is closed when the device is disconnected from the workflow.
+func (device *Device) Done() <-chan struct{} {
+	return device.Session.Done()
+}
+
+// Disconnects the device without stopping the workflow first.
+func (device *Device) Close() error {
+	return device.Session.Close()
+}
+
+func (device *Device) say(req relaytest.Request) []map[string]interface{} {
+	text := req.String("text")
+	device.printf("says: %q", text)
+	return device.prompt(req, device.wordDuration*time.Duration(len(strings.Fields(text))+1))
+}
+
+func (device *Device) play(req relaytest.Request) []map[string]interface{} {
+	device.printf("plays: %s", req.String("filename"))
+	return device.prompt(req, device.playDuration)
+}
+
+// answers with the response and the prompt started event, and sends the prompt stopped
+// event once the device is done talking
+func (device *Device) prompt(req relaytest.Request, duration time.Duration) []map[string]interface{} {
+	frames := device.Session.DefaultResponse(req)
+	stopped := frames[len(frames)-1]
+	time.AfterFunc(duration, func() {
+		device.Session.Send(stopped)
+	})
+	return frames[:len(frames)-1]
+}
+
+func (device *Device) listen(req relaytest.Request) []map[string]interface{} {
+	response := map[string]interface{}{"_type": "wf_api_listen_response", "_id": req.Id}
+	device.mutex.Lock()
+	if len(device.speech) > 0 {
+		text := device.speech[0]
+		device.speech = device.speech[1:]
+		device.mutex.Unlock()
+		return []map[string]interface{}{response, device.speechEvent(req, text)}
+	}
+	device.listens = append(device.listens, req)
+	device.mutex.Unlock()
+
+	if phrases, _ := req.Fields["phrases"].([]interface{}); len(phrases) > 0 {
+		device.printf("is listening for %v, type what to say", phrases)
+	} else {
+		device.printf("is listening, type what to say")
+	}
+	if timeout, _ := req.Fields["timeout"].(float64); timeout > 0 {
+		time.AfterFunc(time.Duration(timeout)*time.Second, func() {
+			device.listenTimedOut(req)
+		})
+	}
+	return []map[string]interface{}{response}
+}
+
+// ends a listen the user did not answer in time, as the Relay server does
+func (device *Device) listenTimedOut(req relaytest.Request) {
+	device.mutex.Lock()
+	waiting := false
+	for i, listen := range device.listens {
+		if listen.Id == req.Id {
+			device.listens = append(device.listens[:i:i], device.listens[i+1:]...)
+			waiting = true
+			break
+		}
+	}
+	device.mutex.Unlock()
+	if waiting {
+		device.printf("stopped listening, nothing was said")
+		device.Session.Send(map[string]interface{}{
+			"_type":        "wf_api_error_response",
+			"_id":          req.Id,
+			"code":         sdk.LISTEN_TIMEOUT_CODE,
+			"message":      "listen timed out",
+			"request_type": "wf_api_listen_request",
+		})
+	}
+}
+
+func (device *Device) speechEvent(req relaytest.Request, text string) map[string]interface{} {
+	device.printf("hears: %q", text)
+	return map[string]interface{}{
+		"_type":      "wf_api_speech_event",
+		"request_id": req.String("request_id"),
+		"text":       text,
+		"lang":       "en-US",
+		"source_uri": device.targetOf(req),
+	}
+}
+
+func (device *Device) startInteraction(req relaytest.Request) []map[string]interface{} {
+	name := req.String("name")
+	interactionUri := sdk.InteractionName(name) + sdk.DEVICE_PATTERN + url.PathEscape(device.Uri)
+	device.mutex.Lock()
+	device.interactions = append(device.interactions, interaction{name: name, uri: interactionUri})
+	device.mutex.Unlock()
+	device.printf("starts interaction %q", name)
+	return append(device.Session.DefaultResponse(req), map[string]interface{}{
+		"_type": "wf_api_interaction_lifecycle_event", "type": "started", "source_uri": interactionUri,
+	})
+}
+
+func (device *Device) endInteraction(req relaytest.Request) []map[string]interface{} {
+	interactionUri := device.targetOf(req)
+	name := interactionUri
+	device.mutex.Lock()
+	for i, started := range device.interactions {
+		if started.uri == interactionUri {
+			name = started.name
+			device.interactions = append(device.interactions[:i:i], device.interactions[i+1:]...)
+			break
+		}
+	}
+	device.mutex.Unlock()
+	device.printf("ends interaction %q", name)
+	return append(device.Session.DefaultResponse(req), map[string]interface{}{
+		"_type": "wf_api_interaction_lifecycle_event", "type": "ended", "source_uri": interactionUri,
+	})
+}
+
+func (device *Device) getDeviceInfo(req relaytest.Request) []map[string]interface{} {
+	frames := device.Session.DefaultResponse(req)
+	for key, value := range map[string]interface{}{
+		"name": device.Name, "id": device.Name, "battery": 100, "type": "relay", "username": device.Name,
+	} {
+		frames[0][key] = value
+	}
+	return frames
+}
+
+func (device *Device) terminate(req relaytest.Request) []map[string]interface{} {
+	device.printf("workflow terminated")
+	return device.Session.DefaultResponse(req)
+}
+
+// prints the field of the request, and answers it with the default response
+func (device *Device) printRequest(what string, field string) relaytest.Responder {
+	return func(req relaytest.Request) []map[string]interface{} {
+		device.printf("%s %v", what, req.Fields[field])
+		return device.Session.DefaultResponse(req)
+	}
+}
+
+// the device or interaction URN the request targets
+func (device *Device) targetOf(req relaytest.Request) string {
+	if len(req.Target) > 0 {
+		return req.Target[0]
+	}
+	return device.Uri
+}
+
+func (device *Device) sourceUri() string {
+	device.mutex.Lock()
+	defer device.mutex.Unlock()
+	if len(device.interactions) > 0 {
+		return device.interactions[len(device.interactions)-1].uri
+	}
+	return device.Uri
+}
+
+func (device *Device) printf(format string, args ...interface{}) {
+	fmt.Fprintf(device.out, "[%s] %s\n", device.Name, fmt.Sprintf(format, args...))
+}
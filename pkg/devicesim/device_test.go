@@ -0,0 +1,204 @@
+// Copyright © 2022 Relay Inc.
+
+package devicesim_test
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"relay-go/pkg/devicesim"
+	"relay-go/pkg/relaytest"
+	"relay-go/pkg/sdk"
+)
+
+const wordDuration = 50 * time.Millisecond
+
+// The output of a device, which prints from the goroutines of its session.
+type output struct {
+	mutex sync.Mutex
+	buf   bytes.Buffer
+}
+
+func (out *output) Write(p []byte) (int, error) {
+	out.mutex.Lock()
+	defer out.mutex.Unlock()
+	return out.buf.Write(p)
+}
+
+func (out *output) String() string {
+	out.mutex.Lock()
+	defer out.mutex.Unlock()
+	return out.buf.String()
+}
+
+// blocks until the device printed text count times
+func (out *output) waitFor(t *testing.T, text string, count int) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for strings.Count(out.String(), text) < count {
+		if time.Now().After(deadline) {
+			t.Fatalf("the device did not print %q %d times, it printed:\n%s", text, count, out.String())
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+// Connects a simulated device to the workflow on a relaytest server.
+func connect(t *testing.T, fn func(api sdk.RelayApi)) (*devicesim.Device, *output) {
+	t.Helper()
+	server := relaytest.NewServer()
+	t.Cleanup(server.Close)
+	server.AddWorkflow("sim", fn)
+	session, err := server.Dial("sim")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { session.Close() })
+	out := &output{}
+	device := devicesim.New(session, "alice", devicesim.WithOutput(out), devicesim.WithPromptDurations(wordDuration, wordDuration))
+	return device, out
+}
+
+func TestSayAndListen(t *testing.T) {
+	type result struct {
+		said   time.Duration
+		sayErr error
+		speech []sdk.SpeechEvent
+		errs   []error
+	}
+	results := make(chan result, 1)
+	device, out := connect(t, func(api sdk.RelayApi) {
+		api.OnStart(func(startEvent sdk.StartEvent) {
+			sourceUri := api.GetSourceUri(startEvent)
+			var r result
+			start := time.Now()
+			_, r.sayErr = api.SayAndWaitCtx(api.Context(), sourceUri, "hello there", sdk.ENGLISH)
+			r.said = time.Since(start)
+			for i := 0; i < 2; i++ {
+				speech, err := api.ListenSpeech(api.Context(), sourceUri, nil, true, sdk.ENGLISH, 30)
+				r.speech = append(r.speech, speech)
+				r.errs = append(r.errs, err)
+			}
+			results <- r
+		})
+	})
+
+	// spoken before the workflow listens, so it answers the first listen
+	device.Speak("Bob")
+	device.Start("")
+	// the second listen waits for the user to speak
+	out.waitFor(t, "is listening", 1)
+	device.Speak("Alice")
+
+	var r result
+	select {
+	case r = <-results:
+	case <-time.After(5 * time.Second):
+		t.Fatalf("the workflow did not finish, the device printed:\n%s", out)
+	}
+	if r.sayErr != nil {
+		t.Errorf("SayAndWait failed: %v", r.sayErr)
+	}
+	// a word per word and one more, see WithPromptDurations
+	if r.said < 3*wordDuration {
+		t.Errorf("SayAndWait returned after %v, before the device stopped talking", r.said)
+	}
+	if !strings.Contains(out.String(), `[alice] says: "hello there"`) {
+		t.Errorf("the device printed:\n%s", out)
+	}
+
+	var requestIds []string
+	for _, req := range device.Session.Requests() {
+		if req.Type == "listen" {
+			requestIds = append(requestIds, req.String("request_id"))
+		}
+	}
+	if len(requestIds) != 2 {
+		t.Fatalf("the workflow sent %v, want two listens", device.Session.RequestTypes())
+	}
+	for i, want := range []string{"Bob", "Alice"} {
+		if r.errs[i] != nil || r.speech[i].Text != want || r.speech[i].RequestId != requestIds[i] {
+			t.Errorf("listen %d got %+v, %v; want %q for request %s", i, r.speech[i], r.errs[i], want, requestIds[i])
+		}
+	}
+}
+
+func TestListenTimeout(t *testing.T) {
+	errs := make(chan error, 1)
+	device, out := connect(t, func(api sdk.RelayApi) {
+		api.OnStart(func(startEvent sdk.StartEvent) {
+			_, err := api.ListenCtx(api.Context(), api.GetSourceUri(startEvent), nil, true, sdk.ENGLISH, 1)
+			errs <- err
+		})
+	})
+	device.Start("")
+
+	select {
+	case err := <-errs:
+		var relayError *sdk.RelayError
+		if !errors.As(err, &relayError) || relayError.Code != sdk.LISTEN_TIMEOUT_CODE {
+			t.Errorf("got %v, want an error with code %q", err, sdk.LISTEN_TIMEOUT_CODE)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("the listen did not time out")
+	}
+	if !strings.Contains(out.String(), "stopped listening") {
+		t.Errorf("the device printed:\n%s", out)
+	}
+}
+
+func TestRunScript(t *testing.T) {
+	var mutex sync.Mutex
+	var events []string
+	record := func(event string) {
+		mutex.Lock()
+		defer mutex.Unlock()
+		events = append(events, event)
+	}
+	device, out := connect(t, func(api sdk.RelayApi) {
+		api.OnStart(func(startEvent sdk.StartEvent) {
+			record("start " + api.GetSourceUri(startEvent))
+		})
+		api.OnButton(func(buttonEvent sdk.ButtonEvent) {
+			record("button " + buttonEvent.Taps)
+		})
+		api.OnStop(func(stopEvent sdk.StopEvent) {
+			record("stop " + stopEvent.Reason)
+		})
+	})
+
+	script := "# a script\n/start\n\n/tap double\n/stop\n/tap\n"
+	if err := device.Run(strings.NewReader(script)); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case <-device.Done():
+	case <-time.After(5 * time.Second):
+		t.Fatal("the device did not disconnect after /stop")
+	}
+
+	want := []string{"start " + device.Uri, "button double", "stop normal"}
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		mutex.Lock()
+		got := append([]string(nil), events...)
+		mutex.Unlock()
+		if reflect.DeepEqual(got, want) {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("the workflow got %v, want %v", got, want)
+		}
+		time.Sleep(time.Millisecond)
+	}
+	for _, line := range []string{"[alice] triggers the workflow", "[alice] taps the button (double)", "[alice] stops the workflow (normal)"} {
+		if !strings.Contains(out.String(), line) {
+			t.Errorf("the device did not print %q, it printed:\n%s", line, out)
+		}
+	}
+}
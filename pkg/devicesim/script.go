@@ -0,0 +1,76 @@
+// Copyright © 2022 Relay Inc.
+
+package devicesim
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// The commands Run understands. Any other line is spoken into the device.
+const USAGE = `/start [phrase]     trigger the workflow, by phrase or by the button
+/tap [taps]         tap the button: single (default), double or triple
+/lifecycle <type>   send an interaction lifecycle event, i.e. suspended or resumed
+/wait <duration>    pause the script, i.e. /wait 2s
+/stop [reason]      stop the workflow, the reason defaults to normal
+/quit               disconnect
+# comment           ignored, as are empty lines
+anything else       say it into the device, see Speak`
+
+// Runs the commands read from input, one per line, i.e. typed at the terminal or from a
+// script, see USAGE. Returns when input ends, after /stop or /quit, or when sending to the
+// workflow fails.
+func (device *Device) Run(input io.Reader) error {
+	scanner := bufio.NewScanner(input)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if !strings.HasPrefix(line, "/") {
+			if err := device.Speak(line); err != nil {
+				return err
+			}
+			continue
+		}
+
+		command, arg, _ := strings.Cut(line[1:], " ")
+		arg = strings.TrimSpace(arg)
+		var err error
+		switch command {
+		case "start":
+			err = device.Start(arg)
+		case "tap":
+			if arg == "" {
+				arg = "single"
+			}
+			err = device.Tap(arg)
+		case "lifecycle":
+			err = device.Lifecycle(arg)
+		case "wait":
+			if duration, parseErr := time.ParseDuration(arg); parseErr == nil {
+				time.Sleep(duration)
+			} else {
+				fmt.Fprintln(device.out, "bad duration:", parseErr)
+			}
+		case "stop":
+			if arg == "" {
+				arg = "normal"
+			}
+			return device.Stop(arg)
+		case "quit":
+			return device.Close()
+		case "help":
+			fmt.Fprintln(device.out, USAGE)
+		default:
+			fmt.Fprintf(device.out, "unknown command %q, try /help\n", line)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return scanner.Err()
+}
@@ -0,0 +1,207 @@
+// Copyright © 2022 Relay Inc.
+
+// Package relaymock provides Mock, a fake sdk.RelayApi for testing the handlers of a workflow
+// without a websocket. The mock records the calls of the workflow, returns what its XxxFunc
+// fields return, and passes the events given to its EmitXxx methods to a pending WaitForEvent
+// or to the registered handlers, and to the subscribers:
+//
+//	mock := &relaymock.Mock{}
+//	mock.ListenFunc = func(sourceUri string, phrases []string, transcribe bool, altLang sdk.Language, timeout int) string {
+//		return "Bob"
+//	}
+//	helloWorkflow(mock)
+//	mock.EmitInteractionLifecycle(sdk.InteractionLifecycleEvent{SourceUri: interactionUri, LifecycleType: "started"})
+//	calls := mock.CallsTo("Say")
+//
+// Most of Mock is generated from the sdk.RelayApi interface, run go generate after changing it.
+package relaymock
+
+//go:generate go run ../../internal/cmd/mockgen -sdk ../sdk -pkg . -out relaymock_gen.go
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+
+	"relay-go/pkg/sdk"
+)
+
+var _ sdk.RelayApi = (*Mock)(nil)
+
+// A call to a method of a Mock.
+type Call struct {
+	Method string
+	Args   []interface{} // a variadic argument is passed as a slice
+}
+
+// The state of a Mock, besides its XxxFunc fields.
+type mockState struct {
+	mutex    sync.Mutex
+	calls    []Call
+	handlers map[string]interface{} // the handlers registered with the OnXxx methods
+	subs     []*subscription
+	waiters  []*waiter // goroutines blocked in WaitForEvent, WaitForButton or WaitForTimer
+}
+
+type subscription struct {
+	event   sdk.Event
+	fn      func(eventWrapper sdk.EventWrapper)
+	filters []sdk.EventFilter
+}
+
+// Returns the calls made so far, in order.
+func (mock *Mock) Calls() []Call {
+	mock.mutex.Lock()
+	defer mock.mutex.Unlock()
+	return append([]Call(nil), mock.calls...)
+}
+
+// Returns the calls made so far to the method, i.e. "Say", in order. Requests made through
+// Async are recorded as calls to their Ctx method, i.e. "SayCtx".
+func (mock *Mock) CallsTo(method string) []Call {
+	var calls []Call
+	for _, call := range mock.Calls() {
+		if call.Method == method {
+			calls = append(calls, call)
+		}
+	}
+	return calls
+}
+
+// Returns the context of the workflow instance, the result of ContextFunc if it is set, or a
+// background context.
+func (mock *Mock) Context() context.Context {
+	mock.record("Context")
+	return mock.context()
+}
+
+// Returns an AsyncApi whose requests call the Ctx methods of the mock, unless AsyncFunc is set.
+func (mock *Mock) Async() sdk.AsyncApi {
+	mock.record("Async")
+	if mock.AsyncFunc != nil {
+		return mock.AsyncFunc()
+	}
+	return mockAsync{mock: mock}
+}
+
+// Adds fn as a subscriber of the event, which Publish calls, and returns a function that
+// removes it again. SubscribeFunc is called too if it is set.
+func (mock *Mock) Subscribe(event sdk.Event, fn func(eventWrapper sdk.EventWrapper), filters ...sdk.EventFilter) (unsubscribe func()) {
+	mock.record("Subscribe", event, fn, filters)
+	sub := &subscription{event: event, fn: fn, filters: filters}
+	mock.mutex.Lock()
+	mock.subs = append(mock.subs, sub)
+	mock.mutex.Unlock()
+
+	var unsubscribeFunc func()
+	if mock.SubscribeFunc != nil {
+		unsubscribeFunc = mock.SubscribeFunc(event, fn, filters...)
+	}
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			mock.unsubscribe(sub)
+			if unsubscribeFunc != nil {
+				unsubscribeFunc()
+			}
+		})
+	}
+}
+
+// Passes the event to the first waiter it matches, then to the subscribers whose filters it
+// matches, in the order they subscribed. Returns how many waiters and subscribers got it. See
+// Event.
+func (mock *Mock) Publish(eventWrapper sdk.EventWrapper) int {
+	published := 0
+	if mock.deliverToWaiter(eventWrapper) {
+		published++
+	}
+	return published + mock.publish(eventWrapper)
+}
+
+// passes the event to the subscribers whose filters it matches, and returns how many got it
+func (mock *Mock) publish(eventWrapper sdk.EventWrapper) int {
+	mock.mutex.Lock()
+	subs := append([]*subscription(nil), mock.subs...)
+	mock.mutex.Unlock()
+	published := 0
+	for _, sub := range subs {
+		if sub.matches(eventWrapper) {
+			sub.fn(eventWrapper)
+			published++
+		}
+	}
+	return published
+}
+
+// Makes an event with the given fields, as the Relay server sends it, i.e. to Publish it.
+func Event(event sdk.Event, fields map[string]interface{}) sdk.EventWrapper {
+	parsedMsg := map[string]interface{}{"_type": "wf_api_" + string(event) + "_event"}
+	for key, value := range fields {
+		parsedMsg[key] = value
+	}
+	msg, _ := json.Marshal(parsedMsg)
+	// decode again, so numbers are float64 as in events from the websocket
+	json.Unmarshal(msg, &parsedMsg)
+	return sdk.EventWrapper{ParsedMsg: parsedMsg, Msg: msg, EventName: event}
+}
+
+// makes the event as the Relay server sends it from a decoded event, i.e. an sdk.ButtonEvent
+func wrapEvent(event sdk.Event, decoded interface{}) sdk.EventWrapper {
+	msg, _ := json.Marshal(decoded)
+	var fields map[string]interface{}
+	json.Unmarshal(msg, &fields)
+	return Event(event, fields)
+}
+
+func (mock *Mock) record(method string, args ...interface{}) {
+	mock.mutex.Lock()
+	defer mock.mutex.Unlock()
+	mock.calls = append(mock.calls, Call{Method: method, Args: args})
+}
+
+func (mock *Mock) setHandler(method string, fn interface{}) {
+	mock.mutex.Lock()
+	defer mock.mutex.Unlock()
+	if mock.handlers == nil {
+		mock.handlers = make(map[string]interface{})
+	}
+	mock.handlers[method] = fn
+}
+
+func (mock *Mock) handler(method string) interface{} {
+	mock.mutex.Lock()
+	defer mock.mutex.Unlock()
+	return mock.handlers[method]
+}
+
+func (mock *Mock) unsubscribe(sub *subscription) {
+	mock.mutex.Lock()
+	defer mock.mutex.Unlock()
+	for i, s := range mock.subs {
+		if s == sub {
+			mock.subs = append(mock.subs[:i:i], mock.subs[i+1:]...)
+			return
+		}
+	}
+}
+
+// the context of the workflow instance, without recording a call
+func (mock *Mock) context() context.Context {
+	if mock.ContextFunc != nil {
+		return mock.ContextFunc()
+	}
+	return context.Background()
+}
+
+func (sub *subscription) matches(eventWrapper sdk.EventWrapper) bool {
+	if eventWrapper.EventName != sub.event {
+		return false
+	}
+	for _, filter := range sub.filters {
+		if !filter(eventWrapper) {
+			return false
+		}
+	}
+	return true
+}
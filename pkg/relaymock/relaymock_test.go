@@ -0,0 +1,135 @@
+// Copyright © 2022 Relay Inc.
+
+package relaymock_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"relay-go/pkg/relaymock"
+	"relay-go/pkg/sdk"
+)
+
+const deviceUri = "urn:relay-resource:name:device:alice"
+
+func TestEmitCallsHandlerAndSubscribers(t *testing.T) {
+	mock := &relaymock.Mock{}
+	var got []string
+	mock.OnButton(func(buttonEvent sdk.ButtonEvent) {
+		got = append(got, "handler "+buttonEvent.Taps)
+	})
+	sdk.SubscribeEvent(mock, sdk.BUTTON, func(buttonEvent sdk.ButtonEvent) {
+		got = append(got, "subscriber "+buttonEvent.Taps)
+	}, sdk.SourceFilter(deviceUri), sdk.ButtonFilter("action", ""))
+	mock.Subscribe(sdk.BUTTON, func(eventWrapper sdk.EventWrapper) {
+		got = append(got, "other device")
+	}, sdk.SourceFilter("urn:relay-resource:name:device:bob"))
+
+	if !mock.EmitButton(sdk.ButtonEvent{SourceUri: deviceUri, Button: "action", Taps: "double"}) {
+		t.Fatal("EmitButton returned false")
+	}
+	want := []string{"handler double", "subscriber double"}
+	if len(got) != len(want) || got[0] != want[0] || got[1] != want[1] {
+		t.Errorf("the event reached %v, want %v", got, want)
+	}
+}
+
+func TestEmitWithoutHandlerOrSubscriber(t *testing.T) {
+	mock := &relaymock.Mock{}
+	if mock.EmitTimerFired(sdk.TimerFiredEvent{Name: "reminder"}) {
+		t.Error("EmitTimerFired returned true without a handler or subscriber")
+	}
+	got := ""
+	unsubscribe := mock.Subscribe(sdk.TIMER_FIRED, func(eventWrapper sdk.EventWrapper) {
+		got, _ = eventWrapper.ParsedMsg["name"].(string)
+	}, sdk.TimerFilter("reminder"))
+	if !mock.EmitTimerFired(sdk.TimerFiredEvent{Name: "reminder"}) || got != "reminder" {
+		t.Errorf("the subscriber got %q", got)
+	}
+	unsubscribe()
+	if mock.EmitTimerFired(sdk.TimerFiredEvent{Name: "reminder"}) {
+		t.Error("EmitTimerFired reached a subscriber that unsubscribed")
+	}
+}
+
+func TestCallsAreRecorded(t *testing.T) {
+	mock := &relaymock.Mock{}
+	mock.ListenFunc = func(sourceUri string, phrases []string, transcribe bool, altLang sdk.Language, timeout int) string {
+		return "Bob"
+	}
+	mock.Say(deviceUri, "what is your name?", sdk.ENGLISH)
+	if name := mock.Listen(deviceUri, nil, true, sdk.ENGLISH, 30); name != "Bob" {
+		t.Errorf("Listen returned %q", name)
+	}
+	calls := mock.CallsTo("Say")
+	if len(calls) != 1 || calls[0].Args[1] != "what is your name?" {
+		t.Errorf("Say calls are %v", calls)
+	}
+}
+
+func TestEmitReachesWaiter(t *testing.T) {
+	mock := &relaymock.Mock{}
+	var handled []string
+	mock.OnButton(func(buttonEvent sdk.ButtonEvent) {
+		handled = append(handled, buttonEvent.SourceUri)
+	})
+	type result struct {
+		buttonEvent sdk.ButtonEvent
+		err         error
+	}
+	got := make(chan result, 1)
+	go func() {
+		buttonEvent, err := mock.WaitForButton(context.Background(), deviceUri, "double")
+		got <- result{buttonEvent, err}
+	}()
+	for len(mock.CallsTo("WaitForButton")) == 0 {
+		time.Sleep(time.Millisecond)
+	}
+
+	// the waiter does not match, the handler gets it
+	otherUri := "urn:relay-resource:name:device:bob"
+	if !mock.EmitButton(sdk.ButtonEvent{SourceUri: otherUri, Button: "action", Taps: "double"}) {
+		t.Error("EmitButton returned false")
+	}
+	select {
+	case r := <-got:
+		t.Fatalf("the waiter got %v, %v for another device", r.buttonEvent, r.err)
+	default:
+	}
+	if !mock.EmitButton(sdk.ButtonEvent{SourceUri: deviceUri, Button: "action", Taps: "double"}) {
+		t.Error("EmitButton returned false")
+	}
+	select {
+	case r := <-got:
+		if r.err != nil || r.buttonEvent.SourceUri != deviceUri || r.buttonEvent.Taps != "double" {
+			t.Errorf("the waiter got %v, %v", r.buttonEvent, r.err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("the waiter did not get the button event")
+	}
+	if len(handled) != 1 || handled[0] != otherUri {
+		t.Errorf("the handler got buttons of %v, want only %s", handled, otherUri)
+	}
+}
+
+func TestWaitWithoutEmit(t *testing.T) {
+	mock := &relaymock.Mock{}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := mock.WaitForTimer(ctx, "reminder"); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("got %v, want the context's error", err)
+	}
+
+	stopped, stop := context.WithCancel(context.Background())
+	stop()
+	mock.ContextFunc = func() context.Context { return stopped }
+	if _, err := mock.WaitForEvent(context.Background(), sdk.SPEECH); !errors.Is(err, sdk.ErrWorkflowStopped) {
+		t.Errorf("got %v, want ErrWorkflowStopped", err)
+	}
+	// nobody is waiting anymore
+	if mock.EmitTimerFired(sdk.TimerFiredEvent{Name: "reminder"}) {
+		t.Error("EmitTimerFired reached a waiter that gave up")
+	}
+}
@@ -0,0 +1,135 @@
+// Copyright © 2022 Relay Inc.
+
+package relaymock
+
+import (
+	"context"
+
+	"relay-go/pkg/sdk"
+)
+
+// A goroutine blocked in WaitForEvent.
+type waiter struct {
+	event   sdk.Event
+	filters []sdk.EventFilter
+	ch      chan sdk.EventWrapper
+}
+
+// Returns the result of WaitForEventFunc if it is set. Otherwise blocks until an event of
+// the given type that matches all of the filters is emitted or published, and returns it, as
+// the workflow instance does: the event is taken by the waiter and not passed to the OnXxx
+// handler, except for START and STOP events, while the subscribers get it as well. Returns
+// an error if ctx or the context of the mock is done first.
+//
+// The call is recorded once the waiter is registered, so an event emitted after CallsTo
+// returns the call reaches the waiter.
+func (mock *Mock) WaitForEvent(ctx context.Context, event sdk.Event, filters ...sdk.EventFilter) (sdk.EventWrapper, error) {
+	if mock.WaitForEventFunc != nil {
+		mock.record("WaitForEvent", ctx, event, filters)
+		return mock.WaitForEventFunc(ctx, event, filters...)
+	}
+	return mock.wait(ctx, "WaitForEvent", []interface{}{ctx, event, filters}, event, filters...)
+}
+
+// Returns the result of WaitForButtonFunc if it is set. Otherwise blocks until a matching
+// button event is emitted, see WaitForEvent.
+func (mock *Mock) WaitForButton(ctx context.Context, sourceUri string, taps string) (sdk.ButtonEvent, error) {
+	if mock.WaitForButtonFunc != nil {
+		mock.record("WaitForButton", ctx, sourceUri, taps)
+		return mock.WaitForButtonFunc(ctx, sourceUri, taps)
+	}
+	eventWrapper, err := mock.wait(ctx, "WaitForButton", []interface{}{ctx, sourceUri, taps}, sdk.BUTTON, sdk.SourceFilter(sourceUri), sdk.ButtonFilter("", taps))
+	if err != nil {
+		return sdk.ButtonEvent{}, err
+	}
+	return sdk.DecodeEvent[sdk.ButtonEvent](eventWrapper)
+}
+
+// Returns the result of WaitForTimerFunc if it is set. Otherwise blocks until the named timer
+// fired event is emitted, see WaitForEvent.
+func (mock *Mock) WaitForTimer(ctx context.Context, name string) (sdk.TimerFiredEvent, error) {
+	if mock.WaitForTimerFunc != nil {
+		mock.record("WaitForTimer", ctx, name)
+		return mock.WaitForTimerFunc(ctx, name)
+	}
+	eventWrapper, err := mock.wait(ctx, "WaitForTimer", []interface{}{ctx, name}, sdk.TIMER_FIRED, sdk.TimerFilter(name))
+	if err != nil {
+		return sdk.TimerFiredEvent{}, err
+	}
+	return sdk.DecodeEvent[sdk.TimerFiredEvent](eventWrapper)
+}
+
+// registers a waiter, records the call of method with args, and blocks until the waiter gets
+// its event
+func (mock *Mock) wait(ctx context.Context, method string, args []interface{}, event sdk.Event, filters ...sdk.EventFilter) (sdk.EventWrapper, error) {
+	w := &waiter{event: event, filters: filters, ch: make(chan sdk.EventWrapper, 1)}
+	mock.mutex.Lock()
+	mock.waiters = append(mock.waiters, w)
+	mock.mutex.Unlock()
+	mock.record(method, args...)
+
+	var err error
+	select {
+	case eventWrapper := <-w.ch:
+		return eventWrapper, nil
+	case <-ctx.Done():
+		err = ctx.Err()
+	case <-mock.context().Done():
+		err = sdk.ErrWorkflowStopped
+	}
+
+	mock.mutex.Lock()
+	mock.removeWaiter(w)
+	mock.mutex.Unlock()
+	// the event may have been delivered while giving up
+	select {
+	case eventWrapper := <-w.ch:
+		return eventWrapper, nil
+	default:
+		return sdk.EventWrapper{}, err
+	}
+}
+
+// Passes the event to the first waiter it matches. Returns true if a waiter took it.
+func (mock *Mock) deliverToWaiter(eventWrapper sdk.EventWrapper) bool {
+	mock.mutex.Lock()
+	var match *waiter
+	for _, w := range mock.waiters {
+		if w.matches(eventWrapper) {
+			match = w
+			break
+		}
+	}
+	if match != nil {
+		mock.removeWaiter(match)
+	}
+	mock.mutex.Unlock()
+
+	if match == nil {
+		return false
+	}
+	match.ch <- eventWrapper
+	return true
+}
+
+// must be called with the mutex held
+func (mock *Mock) removeWaiter(w *waiter) {
+	for i, other := range mock.waiters {
+		if other == w {
+			mock.waiters = append(mock.waiters[:i:i], mock.waiters[i+1:]...)
+			return
+		}
+	}
+}
+
+func (w *waiter) matches(eventWrapper sdk.EventWrapper) bool {
+	if eventWrapper.EventName != w.event {
+		return false
+	}
+	for _, filter := range w.filters {
+		if !filter(eventWrapper) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,51 @@
+// Copyright © 2022 Relay Inc.
+
+// Package relaytest runs workflows against an in-process fake of the Relay server, so they
+// can be tested without the Relay cloud or a device.
+//
+// A Server serves the workflows with net/http/httptest. Dialing a workflow starts an instance
+// of it, and returns the Session that plays the Relay server's side of its websocket: it sends
+// events to the workflow, answers its requests, and records them.
+//
+//	server := relaytest.NewServer()
+//	defer server.Close()
+//	server.AddWorkflow("hello", helloWorkflow)
+//	session, err := server.Dial("hello")
+//	...
+//	session.SendStart(deviceUri)
+//	req, err := session.WaitForRequest(ctx, "say")
+package relaytest
+
+import (
+	"net/http/httptest"
+	"strings"
+
+	"relay-go/pkg/sdk"
+)
+
+// A Server is an sdk.Server listening on a local httptest server.
+type Server struct {
+	*httptest.Server
+	Relay *sdk.Server // the server running the workflows
+}
+
+// Starts a Server configured with the given options. Close it when done.
+func NewServer(opts ...sdk.ServerOption) *Server {
+	relay := sdk.NewServer(opts...)
+	return &Server{Server: httptest.NewServer(relay), Relay: relay}
+}
+
+// Registers a workflow on the server, see sdk.Server.AddWorkflow.
+func (server *Server) AddWorkflow(workflowName string, fn func(api sdk.RelayApi)) {
+	server.Relay.AddWorkflow(workflowName, fn)
+}
+
+// Connects to the workflow as the Relay server does, which starts an instance of it.
+func (server *Server) Dial(workflowName string) (*Session, error) {
+	return Dial(server.WorkflowURL(workflowName))
+}
+
+// Returns the websocket URL of the workflow.
+func (server *Server) WorkflowURL(workflowName string) string {
+	return "ws" + strings.TrimPrefix(server.URL, "http") + "/" + workflowName
+}
@@ -0,0 +1,153 @@
+// Copyright © 2022 Relay Inc.
+
+package relaytest
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+
+	"relay-go/pkg/sdk"
+)
+
+// Reads a recording written with sdk.WithRecorder or sdk.WithRecordingDir.
+func LoadRecording(path string) ([]sdk.RecordedFrame, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	return ReadRecording(file)
+}
+
+// Same as LoadRecording, but reads the recording from r.
+func ReadRecording(r io.Reader) ([]sdk.RecordedFrame, error) {
+	var recording []sdk.RecordedFrame
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(nil, 16*1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		var frame sdk.RecordedFrame
+		if err := json.Unmarshal(scanner.Bytes(), &frame); err != nil {
+			return nil, fmt.Errorf("recording line %d: %w", line, err)
+		}
+		recording = append(recording, frame)
+	}
+	return recording, scanner.Err()
+}
+
+// A ReplayError tells where a replayed workflow stopped following its recording.
+type ReplayError struct {
+	Index    int               // the index of the recorded frame, the length of the recording after its end
+	Expected sdk.RecordedFrame // the request the workflow sent when it was recorded, none after the end
+	Actual   *Request          // the request it sent instead, nil if it sent none
+	Err      error             // why no request was sent, if Actual is nil
+}
+
+func (replayError *ReplayError) Error() string {
+	if replayError.Expected.Frame == nil {
+		return fmt.Sprintf("workflow sent %s after the end of the recording", replayError.Actual.Frame)
+	}
+	if replayError.Actual == nil {
+		return fmt.Sprintf("recorded frame %d: workflow did not send %s: %v", replayError.Index, replayError.Expected.Frame, replayError.Err)
+	}
+	return fmt.Sprintf("recorded frame %d: workflow sent %s instead of %s", replayError.Index, replayError.Actual.Frame, replayError.Expected.Frame)
+}
+
+func (replayError *ReplayError) Unwrap() error {
+	return replayError.Err
+}
+
+// Replays a recording against the workflow, see Replay.
+func (server *Server) Replay(ctx context.Context, workflowName string, recording []sdk.RecordedFrame) (*Session, error) {
+	return Replay(ctx, server.WorkflowURL(workflowName), recording)
+}
+
+// Starts an instance of the workflow at the websocket URL and plays the Relay server's side
+// of a recording to it: each inbound frame is sent once the workflow sent the requests
+// recorded before it, which have to match the recorded ones in the recorded order; any other
+// request, including one sent by the end of the replay, fails it. The ids of the requests are
+// mapped to the ids the instance uses, so the recorded responses answer the right requests;
+// configure the server with sdk.WithIdGenerator(sdk.SequentialIds) to make them the same.
+// Returns the session, to inspect the requests, and a *ReplayError if the workflow did not
+// send a recorded request before ctx is done, or sent another one. The session does not answer
+// requests itself.
+func Replay(ctx context.Context, url string, recording []sdk.RecordedFrame) (*Session, error) {
+	session, err := dial(url, false)
+	if err != nil {
+		return nil, err
+	}
+	ids := make(map[string]string) // recorded request id to the id used in the replay
+	for i, recorded := range recording {
+		var fields map[string]interface{}
+		if json.Unmarshal(recorded.Frame, &fields) != nil {
+			// a malformed frame, invalid JSON is recorded as a JSON string
+			if recorded.Direction == sdk.RECORD_INBOUND {
+				text := []byte(recorded.Frame)
+				var invalid string
+				if json.Unmarshal(recorded.Frame, &invalid) == nil {
+					text = []byte(invalid)
+				}
+				if err := session.SendText(text); err != nil {
+					return session, err
+				}
+			}
+			continue
+		}
+
+		if recorded.Direction == sdk.RECORD_INBOUND {
+			for _, key := range []string{"_id", "request_id"} {
+				if id, ok := fields[key].(string); ok && ids[id] != "" {
+					fields[key] = ids[id]
+				}
+			}
+			if err := session.Send(fields); err != nil {
+				return session, err
+			}
+			if fields["_type"] == "wf_api_stop_event" {
+				session.shutdown()
+			}
+			continue
+		}
+
+		msgType, _ := fields["_type"].(string)
+		if !requestRegex.MatchString(msgType) {
+			continue
+		}
+		// the next request, whatever its type, so an unexpected one fails the replay
+		req, err := session.waitForRequest(ctx, "")
+		if err != nil {
+			return session, &ReplayError{Index: i, Expected: recorded, Err: err}
+		}
+		if !sameRequest(fields, req.Fields) {
+			return session, &ReplayError{Index: i, Expected: recorded, Actual: &req}
+		}
+		if id, _ := fields["_id"].(string); id != "" {
+			ids[id] = req.Id
+		}
+	}
+	if untaken := session.untakenRequests(); len(untaken) > 0 {
+		return session, &ReplayError{Index: len(recording), Actual: &untaken[0]}
+	}
+	return session, nil
+}
+
+// compares two requests, except for their ids
+func sameRequest(recorded map[string]interface{}, actual map[string]interface{}) bool {
+	without := func(fields map[string]interface{}) map[string]interface{} {
+		copied := make(map[string]interface{}, len(fields))
+		for key, value := range fields {
+			if key != "_id" && key != "request_id" {
+				copied[key] = value
+			}
+		}
+		return copied
+	}
+	return reflect.DeepEqual(without(recorded), without(actual))
+}
@@ -0,0 +1,108 @@
+// Copyright © 2022 Relay Inc.
+
+package relaytest_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"reflect"
+	"testing"
+	"time"
+
+	"relay-go/pkg/relaytest"
+	"relay-go/pkg/sdk"
+)
+
+const deviceUri = "urn:relay-resource:name:device:alice"
+
+// a recording buffer, closed when the workflow instance ends
+type recording struct {
+	bytes.Buffer
+	closed chan struct{}
+}
+
+func (rec *recording) Close() error {
+	close(rec.closed)
+	return nil
+}
+
+func greeter(extraRequest bool) func(api sdk.RelayApi) {
+	return func(api sdk.RelayApi) {
+		api.OnStart(func(startEvent sdk.StartEvent) {
+			api.SayAndWait(deviceUri, "What is your name?", sdk.ENGLISH)
+			name := api.Listen(deviceUri, nil, true, sdk.ENGLISH, 30)
+			if extraRequest {
+				api.SetVar("name", name)
+			}
+			api.Say(deviceUri, "Hello "+name, sdk.ENGLISH)
+			api.Terminate()
+		})
+	}
+}
+
+// records a run of the greeter against a session
+func record(t *testing.T) []sdk.RecordedFrame {
+	t.Helper()
+	rec := &recording{closed: make(chan struct{})}
+	server := relaytest.NewServer(
+		sdk.WithIdGenerator(sdk.SequentialIds),
+		sdk.WithRecorder(func(workflowName string) (io.WriteCloser, error) { return rec, nil }),
+	)
+	defer server.Close()
+	server.AddWorkflow("greeter", greeter(false))
+	session, err := server.Dial("greeter")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer session.Close()
+	session.QueueSpeech("Bob")
+	session.SendStart(deviceUri)
+	select {
+	case <-rec.closed:
+	case <-time.After(5 * time.Second):
+		t.Fatal("the recorded workflow did not terminate")
+	}
+	frames, err := relaytest.ReadRecording(&rec.Buffer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return frames
+}
+
+func replay(t *testing.T, frames []sdk.RecordedFrame, extraRequest bool) (*relaytest.Session, error) {
+	t.Helper()
+	server := relaytest.NewServer(sdk.WithIdGenerator(sdk.SequentialIds))
+	t.Cleanup(server.Close)
+	server.AddWorkflow("greeter", greeter(extraRequest))
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	session, err := server.Replay(ctx, "greeter", frames)
+	if session != nil {
+		t.Cleanup(func() { session.Close() })
+	}
+	return session, err
+}
+
+func TestRecordAndReplay(t *testing.T) {
+	frames := record(t)
+	session, err := replay(t, frames, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"say", "listen", "say", "terminate"}
+	if got := session.RequestTypes(); !reflect.DeepEqual(got, want) {
+		t.Errorf("replay sent %v, want %v", got, want)
+	}
+}
+
+// A request that was not recorded fails the replay, even if the recorded ones follow it.
+func TestReplayUnexpectedRequest(t *testing.T) {
+	frames := record(t)
+	_, err := replay(t, frames, true)
+	var replayError *relaytest.ReplayError
+	if !errors.As(err, &replayError) || replayError.Actual == nil || replayError.Actual.Type != "set_var" {
+		t.Fatalf("replay failed with %v, want a ReplayError for the set_var request", err)
+	}
+}
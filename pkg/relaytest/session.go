@@ -0,0 +1,366 @@
+// Copyright © 2022 Relay Inc.
+
+package relaytest
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"relay-go/pkg/sdk"
+)
+
+// A request sent by the workflow.
+type Request struct {
+	Type   string                 // the request type, i.e. "say" for wf_api_say_request
+	Id     string                 // the _id the response has to carry
+	Target []string               // the URNs in _target
+	Fields map[string]interface{} // the decoded frame
+	Frame  []byte                 // the raw frame
+}
+
+// Returns the string field of the request, i.e. "text" of a say request.
+func (req Request) String(key string) string {
+	value, _ := req.Fields[key].(string)
+	return value
+}
+
+// A Responder answers a request with the frames it returns, i.e. a response and the events
+// that follow it. It may return nil to leave the request unanswered.
+type Responder func(req Request) []map[string]interface{}
+
+var requestRegex = regexp.MustCompile(`^wf_api_(.+)_request$`)
+
+// A Session plays the Relay server's side of the websocket of one workflow instance. It
+// answers each request of the workflow, by default with a response of the right type; say and
+// play requests are also followed by prompt started and stopped events, listen requests by a
+// speech event with the next text given to QueueSpeech, and a terminate request by a stop
+// event. All methods are safe for concurrent use.
+type Session struct {
+	conn       *websocket.Conn
+	writeMutex sync.Mutex
+
+	mutex      sync.Mutex
+	requests   []Request
+	taken      map[int]bool // indices of the requests returned by WaitForRequest
+	responders map[string]Responder
+	speech     []string
+	nextId     int
+	recorded   chan struct{} // closed and replaced when a request is recorded
+	answer     bool          // false while replaying, which sends the recorded answers
+
+	done chan struct{} // closed when the websocket is closed
+}
+
+// Connects to a workflow's websocket URL as the Relay server does, which starts an instance
+// of the workflow.
+func Dial(url string) (*Session, error) {
+	return dial(url, true)
+}
+
+func dial(url string, answer bool) (*Session, error) {
+	conn, _, err := websocket.DefaultDialer.Dial(url, nil)
+	if err != nil {
+		return nil, err
+	}
+	session := &Session{
+		conn:       conn,
+		taken:      make(map[int]bool),
+		responders: make(map[string]Responder),
+		recorded:   make(chan struct{}),
+		done:       make(chan struct{}),
+		answer:     answer,
+	}
+	go session.read()
+	return session, nil
+}
+
+// Sets the responder for a request type, i.e. "get_device_info", instead of the default.
+func (session *Session) Respond(requestType string, responder Responder) {
+	session.mutex.Lock()
+	defer session.mutex.Unlock()
+	session.responders[requestType] = responder
+}
+
+// Answers requests of the type with the default frames, with fields added to the response.
+func (session *Session) RespondWith(requestType string, fields map[string]interface{}) {
+	session.Respond(requestType, func(req Request) []map[string]interface{} {
+		frames := session.DefaultResponse(req)
+		if len(frames) > 0 {
+			for key, value := range fields {
+				frames[0][key] = value
+			}
+		}
+		return frames
+	})
+}
+
+// Answers requests of the type with an error response, which the workflow's Ctx requests
+// return as an *sdk.RelayError.
+func (session *Session) RespondError(requestType string, code string, message string) {
+	session.Respond(requestType, func(req Request) []map[string]interface{} {
+		return []map[string]interface{}{{
+			"_type":        "wf_api_error_response",
+			"_id":          req.Id,
+			"code":         code,
+			"message":      message,
+			"request_type": "wf_api_" + req.Type + "_request",
+		}}
+	})
+}
+
+// Queues the texts the user 'says' in answer to the next listen requests, one per listen. A
+// listen without queued text gets a speech event with an empty text.
+func (session *Session) QueueSpeech(texts ...string) {
+	session.mutex.Lock()
+	defer session.mutex.Unlock()
+	session.speech = append(session.speech, texts...)
+}
+
+// Returns the frames the session answers the request with by default.
+func (session *Session) DefaultResponse(req Request) []map[string]interface{} {
+	response := map[string]interface{}{"_type": "wf_api_" + req.Type + "_response", "_id": req.Id}
+	sourceUri := ""
+	if len(req.Target) > 0 {
+		sourceUri = req.Target[0]
+	}
+	switch req.Type {
+	case "terminate":
+		// the Relay server stops the workflow instead of answering, see read
+		return []map[string]interface{}{{"_type": "wf_api_stop_event", "reason": "normal"}}
+	case "say", "play":
+		promptId := session.makeId("prompt")
+		response["id"] = promptId
+		return []map[string]interface{}{
+			response,
+			{"_type": "wf_api_prompt_event", "type": "started", "id": promptId, "source_uri": sourceUri},
+			{"_type": "wf_api_prompt_event", "type": "stopped", "id": promptId, "source_uri": sourceUri},
+		}
+	case "listen":
+		session.mutex.Lock()
+		text := ""
+		if len(session.speech) > 0 {
+			text = session.speech[0]
+			session.speech = session.speech[1:]
+		}
+		session.mutex.Unlock()
+		requestId := req.String("request_id")
+		return []map[string]interface{}{
+			response,
+			{"_type": "wf_api_speech_event", "request_id": requestId, "text": text, "lang": "en-US", "source_uri": sourceUri},
+		}
+	}
+	return []map[string]interface{}{response}
+}
+
+// Sends a start event, as if the workflow was triggered by sourceUri.
+func (session *Session) SendStart(sourceUri string) error {
+	return session.SendEvent(sdk.START, map[string]interface{}{
+		"trigger": map[string]interface{}{
+			"type": sdk.BUTTON_TRIGGER,
+			"args": map[string]interface{}{"source_uri": sourceUri},
+		},
+	})
+}
+
+// Sends an interaction lifecycle event, i.e. "started" or "ended", for the interaction URN.
+func (session *Session) SendLifecycle(interactionUri string, lifecycleType string) error {
+	return session.SendEvent(sdk.INTERACTION_LIFECYCLE, map[string]interface{}{"source_uri": interactionUri, "type": lifecycleType})
+}
+
+// Sends a button event, i.e. button "action" with taps "double".
+func (session *Session) SendButton(sourceUri string, button string, taps string) error {
+	return session.SendEvent(sdk.BUTTON, map[string]interface{}{"source_uri": sourceUri, "button": button, "taps": taps})
+}
+
+// Sends a timer fired event for the named timer.
+func (session *Session) SendTimerFired(name string) error {
+	return session.SendEvent(sdk.TIMER_FIRED, map[string]interface{}{"name": name})
+}
+
+// Sends a stop event with the reason, i.e. "normal", and closes the websocket, as the Relay
+// server does.
+func (session *Session) SendStop(reason string) error {
+	err := session.SendEvent(sdk.STOP, map[string]interface{}{"reason": reason})
+	session.shutdown()
+	return err
+}
+
+// Sends an event of any type with the given fields.
+func (session *Session) SendEvent(event sdk.Event, fields map[string]interface{}) error {
+	frame := map[string]interface{}{"_type": "wf_api_" + string(event) + "_event"}
+	for key, value := range fields {
+		frame[key] = value
+	}
+	return session.Send(frame)
+}
+
+// Sends a raw frame to the workflow.
+func (session *Session) Send(frame interface{}) error {
+	session.writeMutex.Lock()
+	defer session.writeMutex.Unlock()
+	return session.conn.WriteJSON(frame)
+}
+
+// Sends a frame that is not JSON, or not valid JSON, to the workflow as is.
+func (session *Session) SendText(frame []byte) error {
+	session.writeMutex.Lock()
+	defer session.writeMutex.Unlock()
+	return session.conn.WriteMessage(websocket.TextMessage, frame)
+}
+
+// Returns the requests the workflow sent so far, in order.
+func (session *Session) Requests() []Request {
+	session.mutex.Lock()
+	defer session.mutex.Unlock()
+	return append([]Request(nil), session.requests...)
+}
+
+// Returns the types of the requests the workflow sent so far, in order.
+func (session *Session) RequestTypes() []string {
+	requests := session.Requests()
+	types := make([]string, len(requests))
+	for i, req := range requests {
+		types[i] = req.Type
+	}
+	return types
+}
+
+// Blocks until the workflow sent a request of the type that no earlier call returned, and
+// returns it. Returns an error if ctx is done or the websocket closes first.
+func (session *Session) WaitForRequest(ctx context.Context, requestType string) (Request, error) {
+	return session.waitForRequest(ctx, requestType)
+}
+
+// Same as WaitForRequest, but an empty requestType matches a request of any type.
+func (session *Session) waitForRequest(ctx context.Context, requestType string) (Request, error) {
+	for {
+		session.mutex.Lock()
+		for i, req := range session.requests {
+			if (requestType == "" || req.Type == requestType) && !session.taken[i] {
+				session.taken[i] = true
+				session.mutex.Unlock()
+				return req, nil
+			}
+		}
+		recorded := session.recorded
+		session.mutex.Unlock()
+
+		select {
+		case <-recorded:
+		case <-ctx.Done():
+			return Request{}, fmt.Errorf("waiting for %s request: %w", requestName(requestType), ctx.Err())
+		case <-session.done:
+			return Request{}, fmt.Errorf("waiting for %s request: websocket closed", requestName(requestType))
+		}
+	}
+}
+
+func requestName(requestType string) string {
+	if requestType == "" {
+		return "a"
+	}
+	return requestType
+}
+
+// Returns the requests no call of WaitForRequest returned yet.
+func (session *Session) untakenRequests() []Request {
+	session.mutex.Lock()
+	defer session.mutex.Unlock()
+	var untaken []Request
+	for i, req := range session.requests {
+		if !session.taken[i] {
+			untaken = append(untaken, req)
+		}
+	}
+	return untaken
+}
+
+// Returns a channel that is closed when the websocket is closed, i.e. after the workflow
+// terminated.
+func (session *Session) Done() <-chan struct{} {
+	return session.done
+}
+
+// Closes the websocket without stopping the workflow first, as if the connection was lost.
+func (session *Session) Close() error {
+	err := session.shutdown()
+	<-session.done
+	return err
+}
+
+func (session *Session) shutdown() error {
+	session.writeMutex.Lock()
+	msg := websocket.FormatCloseMessage(websocket.CloseNormalClosure, "")
+	session.conn.WriteControl(websocket.CloseMessage, msg, time.Now().Add(time.Second))
+	session.writeMutex.Unlock()
+	return session.conn.Close()
+}
+
+// reads the requests of the workflow, records and answers them
+func (session *Session) read() {
+	defer close(session.done)
+	for {
+		_, frame, err := session.conn.ReadMessage()
+		if err != nil {
+			return
+		}
+		var fields map[string]interface{}
+		if json.Unmarshal(frame, &fields) != nil {
+			continue
+		}
+		msgType, _ := fields["_type"].(string)
+		matches := requestRegex.FindStringSubmatch(msgType)
+		if matches == nil {
+			continue
+		}
+		req := Request{Type: matches[1], Fields: fields, Frame: frame}
+		req.Id, _ = fields["_id"].(string)
+		if target, ok := fields["_target"].(map[string]interface{}); ok {
+			uris, _ := target["uris"].([]interface{})
+			for _, uri := range uris {
+				if uri, ok := uri.(string); ok {
+					req.Target = append(req.Target, uri)
+				}
+			}
+		}
+
+		session.mutex.Lock()
+		session.requests = append(session.requests, req)
+		close(session.recorded)
+		session.recorded = make(chan struct{})
+		responder := session.responders[req.Type]
+		session.mutex.Unlock()
+		if !session.answer {
+			continue
+		}
+
+		var frames []map[string]interface{}
+		if responder != nil {
+			frames = responder(req)
+		} else {
+			frames = session.DefaultResponse(req)
+		}
+		for _, frame := range frames {
+			if session.Send(frame) != nil {
+				return
+			}
+			if frame["_type"] == "wf_api_stop_event" {
+				session.shutdown()
+				return
+			}
+		}
+	}
+}
+
+func (session *Session) makeId(prefix string) string {
+	session.mutex.Lock()
+	defer session.mutex.Unlock()
+	session.nextId++
+	return prefix + "-" + strconv.Itoa(session.nextId)
+}
@@ -4,6 +4,7 @@ package sdk
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
@@ -17,8 +18,8 @@ import (
 	log "github.com/sirupsen/logrus"
 )
 
-var workflowMap map[string]func(api RelayApi) = make(map[string]func(api RelayApi))
-
+// RelayApi is safe for concurrent use: handlers may spawn goroutines that make requests,
+// and the requests are written to the websocket one at a time, in the order they are made.
 type RelayApi interface { // this is interface of your custom workflow, you implement this, then we call it and pass in the ws
 	// assigning callbacks
 	OnStart(fn func(startEvent StartEvent))
@@ -42,6 +43,13 @@ type RelayApi interface { // this is interface of your custom workflow, you impl
 	OnSms(fn func(smsEvent SmsEvent))
 	OnIncident(fn func(incidentEvent IncidentEvent))
 	OnResume(fn func(resumeEvent ResumeEvent))
+	OnError(fn func(relayError *RelayError))
+	OnUnknownEvent(fn func(eventWrapper EventWrapper))
+	OnDisconnect(fn func(err error))
+	Subscribe(event Event, fn func(eventWrapper EventWrapper), filters ...EventFilter) (unsubscribe func())
+	WaitForEvent(ctx context.Context, event Event, filters ...EventFilter) (EventWrapper, error)
+	WaitForButton(ctx context.Context, sourceUri string, taps string) (ButtonEvent, error)
+	WaitForTimer(ctx context.Context, name string) (TimerFiredEvent, error)
 
 	// api
 	GetSourceUri(startEvent StartEvent) string
@@ -50,6 +58,7 @@ type RelayApi interface { // this is interface of your custom workflow, you impl
 	SetTimer(timerType TimerType, name string, timeout uint64, timeoutType TimeoutType) SetTimerResponse
 	ClearTimer(name string) ClearTimerResponse
 	StartTimer(timeout int) StartTimerResponse // need to test timers
+	StopTimer() StopTimerResponse
 	CreateIncident(originator string, itype string) CreateIncidentResponse
 	ResolveIncident(incidentId string, reason string) ResolveIncidentResponse
 	Say(sourceUri string, text string, lang Language) SayResponse
@@ -107,17 +116,104 @@ type RelayApi interface { // this is interface of your custom workflow, you impl
 	AnswerCall(sourceUri string, callId string) AnswerResponse
 	HangupCall(targetUri string, callId string) HangupCallResponse
 	Terminate()
+
+	// context aware api, each request is bounded by ctx as well as the lifetime of the
+	// workflow instance, and returns an error when the request fails instead of a zero value:
+	// when it cannot be sent, times out, the response cannot be decoded, or the Relay server
+	// answers with an error response
+	Context() context.Context
+	Async() AsyncApi
+	SayTargets(ctx context.Context, targetUris []string, text string, lang Language) (TargetResults[SayResponse], error)
+	PlayTargets(ctx context.Context, targetUris []string, filename string) (TargetResults[PlayResponse], error)
+	VibrateTargets(ctx context.Context, targetUris []string, pattern []int64) (TargetResults[VibrateResponse], error)
+	SetLedTargets(ctx context.Context, targetUris []string, effect LedEffect, args LedInfo) (TargetResults[SetLedResponse], error)
+	SetChannelTargets(ctx context.Context, targetUris []string, channelName string, suppressTTS bool, disableHomeChannel bool) (TargetResults[SetChannelResponse], error)
+	StartInteractionCtx(ctx context.Context, sourceUri string, name string) (StartInteractionResponse, error)
+	EndInteractionCtx(ctx context.Context, sourceUri string) (EndInteractionResponse, error)
+	SetTimerCtx(ctx context.Context, timerType TimerType, name string, timeout uint64, timeoutType TimeoutType) (SetTimerResponse, error)
+	ClearTimerCtx(ctx context.Context, name string) (ClearTimerResponse, error)
+	StartTimerCtx(ctx context.Context, timeout int) (StartTimerResponse, error)
+	StopTimerCtx(ctx context.Context) (StopTimerResponse, error)
+	CreateIncidentCtx(ctx context.Context, originator string, itype string) (CreateIncidentResponse, error)
+	ResolveIncidentCtx(ctx context.Context, incidentId string, reason string) (ResolveIncidentResponse, error)
+	SayCtx(ctx context.Context, sourceUri string, text string, lang Language) (SayResponse, error)
+	AlertCtx(ctx context.Context, target string, originator string, name string, text string, pushOptions NotificationOptions) (SendNotificationResponse, error)
+	CancelAlertCtx(ctx context.Context, target string, name string) (SendNotificationResponse, error)
+	SayAndWaitCtx(ctx context.Context, sourceUri string, text string, lang Language) (SayResponse, error)
+	ListenCtx(ctx context.Context, sourceUri string, phrases []string, transcribe bool, alt_lang Language, timeout int) (string, error)
+	ListenSpeech(ctx context.Context, sourceUri string, phrases []string, transcribe bool, alt_lang Language, timeout int) (SpeechEvent, error)
+	ListenFor(ctx context.Context, sourceUri string, opts ListenOptions) (ListenResult, error)
+	TranslateCtx(ctx context.Context, sourceUri string, text string, from Language, to Language) (string, error)
+	LogMessageCtx(ctx context.Context, message string, category string) (LogAnalyticsEventResponse, error)
+	LogUserMessageCtx(ctx context.Context, message string, sourceUri string, category string) (LogAnalyticsEventResponse, error)
+	SetVarCtx(ctx context.Context, name string, value string) (SetVarResponse, error)
+	UnsetVarCtx(ctx context.Context, name string) (UnsetVarResponse, error)
+	GetVarCtx(ctx context.Context, name string, defaultValue string) (string, error)
+	GetNumberVarCtx(ctx context.Context, name string, defaultValue int) (int, error)
+	PlayCtx(ctx context.Context, sourceUri string, filename string) (string, error)
+	PlayAndWaitCtx(ctx context.Context, sourceUri string, filename string) (string, error)
+	StopPlaybackCtx(ctx context.Context, sourceUri string, ids []string) (StopPlaybackResponse, error)
+	GetUnreadInboxSizeCtx(ctx context.Context, sourceUri string) (int, error)
+	PlayUnreadInboxMessagesCtx(ctx context.Context, sourceUri string) (PlayInboxMessagesResponse, error)
+	SwitchLedOnCtx(ctx context.Context, sourceUri string, led int, color string) (SetLedResponse, error)
+	SwitchAllLedOnCtx(ctx context.Context, sourceUri string, color string) (SetLedResponse, error)
+	SwitchAllLedOffCtx(ctx context.Context, sourceUri string) (SetLedResponse, error)
+	RainbowCtx(ctx context.Context, sourceUri string, rotations int64) (SetLedResponse, error)
+	RotateCtx(ctx context.Context, sourceUri string, color string, rotations int64) (SetLedResponse, error)
+	FlashCtx(ctx context.Context, sourceUri string, color string, count int64) (SetLedResponse, error)
+	BreatheCtx(ctx context.Context, sourceUri string, color string, count int64) (SetLedResponse, error)
+	VibrateCtx(ctx context.Context, sourceUri string, pattern []int64) (VibrateResponse, error)
+	BroadcastCtx(ctx context.Context, target string, originator string, name string, text string, pushOptions NotificationOptions) (SendNotificationResponse, error)
+	CancelBroadcastCtx(ctx context.Context, target string, name string) (SendNotificationResponse, error)
+	GetDeviceNameCtx(ctx context.Context, sourceUri string, refresh bool) (string, error)
+	GetDeviceIdCtx(ctx context.Context, sourceUri string, refresh bool) (string, error)
+	GetDeviceAddressCtx(ctx context.Context, sourceUri string, refresh bool) (string, error)
+	GetDeviceLocationCtx(ctx context.Context, sourceUri string, refresh bool) (string, error)
+	GetDeviceLatLongCtx(ctx context.Context, sourceUri string, refresh bool) ([]float64, error)
+	IsGroupMemberCtx(ctx context.Context, groupNameUri string, potentialMemberUri string) (bool, error)
+	GetGroupMembersCtx(ctx context.Context, groupUri string) ([]string, error)
+	GetDeviceCoordinatesCtx(ctx context.Context, sourceUri string, refresh bool) ([]float64, error)
+	GetDeviceIndoorLocationCtx(ctx context.Context, sourceUri string, refresh bool) (string, error)
+	GetDeviceBatteryCtx(ctx context.Context, sourceUri string, refresh bool) (uint64, error)
+	GetDeviceTypeCtx(ctx context.Context, sourceUri string, refresh bool) (string, error)
+	GetUserProfileCtx(ctx context.Context, sourceUri string, refresh bool) (string, error)
+	GetDeviceLocationEnabledCtx(ctx context.Context, sourceUri string, refresh bool) (bool, error)
+	SetDeviceNameCtx(ctx context.Context, sourceUri string, name string) (SetDeviceInfoResponse, error)
+	EnableHomeChannelCtx(ctx context.Context, sourceUri string) (SetHomeChannelStateResponse, error)
+	DisableHomeChannelCtx(ctx context.Context, sourceUri string) (SetHomeChannelStateResponse, error)
+	EnableLocationCtx(ctx context.Context, sourceUri string) (SetDeviceInfoResponse, error)
+	DisableLocationCtx(ctx context.Context, sourceUri string) (SetDeviceInfoResponse, error)
+	SetUserProfileCtx(ctx context.Context, sourceUri string, username string, force bool) (SetUserProfileResponse, error)
+	SetChannelCtx(ctx context.Context, sourceUri string, channelName string, suppressTTS bool, disableHomeChannel bool) (SetChannelResponse, error)
+	PlaceCallCtx(ctx context.Context, targetUri string, uri string) (PlaceCallResponse, error)
+	AnswerCallCtx(ctx context.Context, sourceUri string, callId string) (AnswerResponse, error)
+	HangupCallCtx(ctx context.Context, targetUri string, callId string) (HangupCallResponse, error)
 }
 
 // This struct implements RelayApi below
 type workflowInstance struct {
 	WebsocketConnection *websocket.Conn
-	Mutex               sync.Mutex       // no initialization, zero value is unlocked mutex. this must not be copied, always pass workflowInstance by pointer
-	Pending             map[string]*Call // map of request ids to the call struct for response pairing
+	Mutex               sync.Mutex               // no initialization, zero value is unlocked mutex. this must not be copied, always pass workflowInstance by pointer
+	Outbox              chan outboundFrame       // frames for the writer goroutine, which is the only one writing to the websocket
+	Pending             map[string]*Call         // map of request ids to the call struct for response pairing
+	Prompts             map[string]chan struct{} // map of prompt correlation ids to the channel closed when the prompt stops
+	Waiters             []*eventWaiter           // goroutines blocked in WaitForEvent
+	WorkflowName        string
 	WorkflowFn          func(api RelayApi)
-
-	EventChannel chan EventWrapper
-	StopReason   string
+	Logger              log.FieldLogger    // tagged with the workflow name
+	Ctx                 context.Context    // done when the workflow instance stops or its websocket closes
+	Cancel              context.CancelFunc // cancels Ctx
+
+	EventQueue    *eventQueue
+	Dispatcher    *dispatcher // nil unless handlers run concurrently
+	StopReason    string
+	Stopping      bool  // set once stop was called
+	DisconnectErr error // why the websocket was lost, if it closed without a STOP event
+	Keepalive     keepalive
+	Recorder      *recorder     // nil unless the server records instances
+	IdGenerator   IdGenerator   // nil for random ids
+	Disconnected  chan struct{} // closed when the websocket read loop exits
+	Done          chan struct{} // closed when the workflow instance has finished
 
 	// stores callback functions for each event type
 	OnStartHandler                func(startEvent StartEvent)
@@ -141,6 +237,11 @@ type workflowInstance struct {
 	OnSmsHandler                  func(smsEvent SmsEvent)
 	OnIncidentHandler             func(incidentEvent IncidentEvent)
 	OnResumeHandler               func(resumeEvent ResumeEvent)
+	OnErrorHandler                func(relayError *RelayError)
+	OnUnknownEventHandler         func(eventWrapper EventWrapper)
+	OnDisconnectHandler           func(err error)
+	Subscriptions                 eventBus
+	PanicHandler                  func(info PanicInfo)
 }
 
 // Call represents an active request
@@ -150,12 +251,18 @@ type Call struct {
 	EventWrapper EventWrapper
 	Done         chan bool
 	Error        error
+
+	// set for SayAndWait and PlayAndWait, which wait for the prompt to stop after the response
+	WaitForPrompt bool
+	PromptId      string        // correlation id of the prompt, from the response
+	PromptStopped chan struct{} // closed when the prompt stopped event arrives
 }
 
 type EventWrapper struct {
 	ParsedMsg map[string]interface{}
 	Msg       []byte
 	EventName Event
+	waited    bool // taken by a WaitForEvent waiter, only the subscribers still get it
 }
 
 // Callback Handlers
@@ -213,7 +320,7 @@ func (wfInst *workflowInstance) OnProgress(fn func(progressEvent ProgressEvent))
 	wfInst.OnProgressHandler = fn
 }
 
-// A decorator for a handler method for the PLAY_INBOX_MESSAGE event (a missed message
+// A decorator for a handler method for the PLAY_INBOX_MESSAGES event (a missed message
 // is being played).
 func (wfInst *workflowInstance) OnPlayInboxMessages(fn func(playInboxMessagesEvent PlayInboxMessagesEvent)) {
 	wfInst.OnPlayInboxMessagesHandler = fn
@@ -283,221 +390,141 @@ func (wfInst *workflowInstance) OnResume(fn func(resumeEvent ResumeEvent)) {
 	wfInst.OnResumeHandler = fn
 }
 
-// API functions
-
-// Helper method for parsing out the source URN from a start event trigger.
-func (wfInst *workflowInstance) GetSourceUri(startEvent StartEvent) string {
-	return startEvent.Trigger.Args.SourceUri
+// A decorator for a handler method for the ERROR event (the Relay server rejected a request).
+// The request that caused the error also returns the RelayError from its Ctx variant.
+func (wfInst *workflowInstance) OnError(fn func(relayError *RelayError)) {
+	wfInst.OnErrorHandler = fn
 }
 
-// Starts an interaction with the user.  Triggers an INTERACTION_STARTED event
-// and allows the user to interact with the device via functions that require an
-// interaction URN. Returns a StartInteractionResponse.
-func (wfInst *workflowInstance) StartInteraction(sourceUri string, name string) StartInteractionResponse {
-	id := makeId()
-	target := makeTargetMap(sourceUri)
-	req := startInteractionRequest{Type: "wf_api_start_interaction_request", Id: id, Targets: target, Name: name}
-	call := wfInst.sendAndReceiveRequest(req, id)
-	res := StartInteractionResponse{}
-	json.Unmarshal(call.EventWrapper.Msg, &res)
-	return res
+// A decorator for a handler method for any event the SDK does not know about, i.e. one
+// added to the Relay server after this version of the SDK. Receives the raw event.
+func (wfInst *workflowInstance) OnUnknownEvent(fn func(eventWrapper EventWrapper)) {
+	wfInst.OnUnknownEventHandler = fn
 }
 
-// Ends an interaction with the user.  Triggers an INTERACTION_ENDED event to signify
-// that the user is done interacting with the device.  Returns an EndInteractionResponse.
-func (wfInst *workflowInstance) EndInteraction(sourceUri string) EndInteractionResponse {
-	id := makeId()
-	target := makeTargetMap(sourceUri)
-	req := endInteractionRequest{Type: "wf_api_end_interaction_request", Id: id, Targets: target}
-	call := wfInst.sendAndReceiveRequest(req, id)
-	res := EndInteractionResponse{}
-	json.Unmarshal(call.EventWrapper.Msg, &res)
-	return res
+// A decorator for a handler method that is called when the websocket to the Relay server
+// is lost without a STOP event, i.e. because the server stopped answering pings. The error
+// matches ErrConnectionLost. Pending requests have already failed with ErrWorkflowStopped.
+func (wfInst *workflowInstance) OnDisconnect(fn func(err error)) {
+	wfInst.OnDisconnectHandler = fn
 }
 
-// Serves as a named timer that can be either interval or timeout.  Allows you to specify
-// the unit of time. Returns a SetTimerResponse.
-func (wfInst *workflowInstance) SetTimer(timerType TimerType, name string, timeout uint64, timeoutType TimeoutType) SetTimerResponse {
-	id := makeId()
-	req := setTimerRequest{Type: "wf_api_set_timer_request", Id: id, TimerType: timerType, Name: name, Timeout: timeout, TimeoutType: timeoutType}
-	call := wfInst.sendAndReceiveRequest(req, id)
-	res := SetTimerResponse{}
-	json.Unmarshal(call.EventWrapper.Msg, &res)
-	return res
+// Adds a handler for the event, which is called with the events that match all of the
+// filters, i.e. SourceFilter(deviceUri). Unlike the OnXxx handlers, any number of handlers
+// can subscribe to an event; they are called in the order they subscribed, after the OnXxx
+// handler. Returns a function that removes the handler. Use SubscribeEvent to receive
+// decoded events.
+func (wfInst *workflowInstance) Subscribe(event Event, fn func(eventWrapper EventWrapper), filters ...EventFilter) (unsubscribe func()) {
+	return wfInst.Subscriptions.subscribe(event, fn, filters)
 }
 
-// Clears the specified timer. Returns a ClearTimerResponse.
-func (wfInst *workflowInstance) ClearTimer(name string) ClearTimerResponse {
-	id := makeId()
-	req := clearTimerRequest{Type: "wf_api_clear_timer_request", Id: id, Name: name}
-	call := wfInst.sendAndReceiveRequest(req, id)
-	res := ClearTimerResponse{}
-	json.Unmarshal(call.EventWrapper.Msg, &res)
-	return res
-}
-
-// Starts an unnamed timer, meaning this will be the only timer on your device.
-// The timer will fire when it reaches the value of the 'timeout' parameter. Returns
-// a StartTimerResponse.
-func (wfInst *workflowInstance) StartTimer(timeout int) StartTimerResponse {
-	id := makeId()
-	req := startTimerRequest{Type: "wf_api_start_timer_request", Id: id, Timeout: timeout}
-	call := wfInst.sendAndReceiveRequest(req, id)
-	res := StartTimerResponse{}
-	json.Unmarshal(call.EventWrapper.Msg, &res)
-	return res
-}
+// API functions
 
-// Stops an unnamed timer.  Returns a StopTimerResponse.
-func (wfInst *workflowInstance) StopTimer() StopTimerResponse {
-	id := makeId()
-	req := stopTimerRequest{Type: "wf_api_stop_timer_request", Id: id}
-	call := wfInst.sendAndReceiveRequest(req, id)
-	res := StopTimerResponse{}
-	json.Unmarshal(call.EventWrapper.Msg, &res)
-	return res
+// Returns a context that is done when the workflow instance stops, either because a STOP
+// event arrived, the websocket closed, or the server is shutting down.  Requests made
+// with the Ctx variants of the api functions are also bounded by this context.
+func (wfInst *workflowInstance) Context() context.Context {
+	return wfInst.Ctx
 }
 
-// Creates an incident that will alert the Relay Dash. Returns a CreateIncidentResponse.
-func (wfInst *workflowInstance) CreateIncident(originator string, itype string) CreateIncidentResponse {
-	id := makeId()
-	req := createIncidentRequest{Type: "wf_api_create_incident_request", Id: id, IncidentType: itype, OriginatorUri: originator}
-	call := wfInst.sendAndReceiveRequest(req, id)
-	res := CreateIncidentResponse{}
-	json.Unmarshal(call.EventWrapper.Msg, &res)
-	return res
+// Helper method for parsing out the source URN from a start event trigger.
+func (wfInst *workflowInstance) GetSourceUri(startEvent StartEvent) string {
+	return startEvent.Trigger.Args.SourceUri
 }
 
-// Resolved an incident that was created. Returns a ResolveIncidentResponse.
-func (wfInst *workflowInstance) ResolveIncident(incidentId string, reason string) ResolveIncidentResponse {
-	id := makeId()
-	req := resolveIncidentRequest{Type: "wf_api_resolve_incident_request", Id: id, IncidentId: incidentId, Reason: reason}
-	call := wfInst.sendAndReceiveRequest(req, id)
-	res := ResolveIncidentResponse{}
-	json.Unmarshal(call.EventWrapper.Msg, &res)
+// Utilizes text to speech capabilities to make the device 'speak' to the user. Returns a SayResponse.
+func (wfInst *workflowInstance) Say(sourceUri string, text string, lang Language) SayResponse {
+	res, _ := wfInst.SayCtx(wfInst.Ctx, sourceUri, text, lang)
 	return res
 }
 
-// Utilizes text to speech capabilities to make the device 'speak' to the user. Returns a SayResponse.
-func (wfInst *workflowInstance) Say(sourceUri string, text string, lang Language) SayResponse {
+// Same as Say, but bounded by ctx. Returns an error if the request fails.
+func (wfInst *workflowInstance) SayCtx(ctx context.Context, sourceUri string, text string, lang Language) (SayResponse, error) {
 	if lang == "" {
 		lang = ENGLISH
 	}
-	log.Debug("saying ", text, " to ", sourceUri, " with lang ", lang)
-	id := makeId()
-	target := makeTargetMap(sourceUri)
-	req := sayRequest{Type: "wf_api_say_request", Id: id, Target: target, Text: text, Lang: lang}
-	call := wfInst.sendAndReceiveRequest(req, id)
-	res := SayResponse{}
-	json.Unmarshal(call.EventWrapper.Msg, &res)
-	return res
+	wfInst.Logger.Debug("saying ", text, " to ", sourceUri, " with lang ", lang)
+	return wfInst.sayCtx(ctx, makeTargetMap(sourceUri), text, lang)
 }
 
 // Utilizes text to speech capabilities to make the device 'speak' to the user.
 // Waits until the text is fully played out on the device before continuing. Returns a SayResponse.
 func (wfInst *workflowInstance) SayAndWait(sourceUri string, text string, lang Language) SayResponse {
+	res, _ := wfInst.SayAndWaitCtx(wfInst.Ctx, sourceUri, text, lang)
+	return res
+}
+
+// Same as SayAndWait, but bounded by ctx. Returns an error if the request fails.
+func (wfInst *workflowInstance) SayAndWaitCtx(ctx context.Context, sourceUri string, text string, lang Language) (SayResponse, error) {
 	if lang == "" {
 		lang = ENGLISH
 	}
-	log.Debug("saying ", text, " to ", sourceUri, " with lang ", lang)
-	id := makeId()
-	target := makeTargetMap(sourceUri)
-	req := sayRequest{Type: "wf_api_say_request", Id: id, Target: target, Text: text, Lang: lang}
-	call := wfInst.sendAndReceiveRequestWait(req, id)
-	res := SayResponse{}
-	json.Unmarshal(call.EventWrapper.Msg, &res)
-	return res
+	wfInst.Logger.Debug("saying ", text, " to ", sourceUri, " with lang ", lang)
+	return wfInst.sayAndWaitCtx(ctx, makeTargetMap(sourceUri), text, lang)
 }
 
 // Listens for the user to speak into the device.  Utilizes speech to text functionality to interact
 // with the user. Returns the text that the device parsed from the speech as a string.
 func (wfInst *workflowInstance) Listen(sourceUri string, phrases []string, transcribe bool, alt_lang Language, timeout int) string {
-	log.Debug("listening ")
-	id := makeId()
-	target := makeTargetMap(sourceUri)
-	req := listenRequest{Type: "wf_api_listen_request", Id: id, Target: target, ReqestId: "request1", Phrases: phrases, Transcribe: transcribe, Timeout: timeout, AltLang: string(alt_lang)}
-	call := wfInst.sendAndReceiveRequest(req, id)
-	res := SpeechEvent{}
-	json.Unmarshal(call.EventWrapper.Msg, &res)
-	return res.Text
+	res, _ := wfInst.ListenCtx(wfInst.Ctx, sourceUri, phrases, transcribe, alt_lang, timeout)
+	return res
 }
 
-// Translates text from one language to another. Returns the translated text in the specified language as a string.
-func (wfInst *workflowInstance) Translate(sourceUri string, text string, from Language, to Language) string {
-	log.Debug("translating ", text)
-	id := makeId()
-	req := translateRequest{Type: "wf_api_translate_request", Id: id, Text: text, FromLang: from, ToLang: to}
-	call := wfInst.sendAndReceiveRequest(req, id)
-	res := TranslateResponse{}
-	json.Unmarshal(call.EventWrapper.Msg, &res)
-	return res.Text
-}
-
-// Log an analytics event from a workflow with the specified content and
-// under a specified category. This does not log the device who
-// triggered the workflow that called this function. Returns a LogAnalyticsEventResponse.
-func (wfInst *workflowInstance) LogMessage(message string, category string) LogAnalyticsEventResponse {
-	log.Debug("logging analytic event with the message ", message)
-	id := makeId()
-	req := logAnalyticsEventRequest{Type: "wf_api_log_analytics_event_request", Id: id, Content: message, ContentType: "default", Category: category}
-	call := wfInst.sendAndReceiveRequest(req, id)
-	res := LogAnalyticsEventResponse{}
-	json.Unmarshal(call.EventWrapper.Msg, &res)
-	return res
+// Same as Listen, but bounded by ctx. Returns an error if the request fails.
+func (wfInst *workflowInstance) ListenCtx(ctx context.Context, sourceUri string, phrases []string, transcribe bool, alt_lang Language, timeout int) (string, error) {
+	res, err := wfInst.ListenSpeech(ctx, sourceUri, phrases, transcribe, alt_lang, timeout)
+	return res.Text, err
 }
 
-// Log an analytic event from a workflow with the specified content and
-// under a specified category.  This includes the device who triggered the workflow
-// that called this function. Returns a LogAnalyticsEventResponse.
-func (wfInst *workflowInstance) LogUserMessage(message string, sourceUri string, category string) LogAnalyticsEventResponse {
-	log.Debug("logging analytic event with the message ", message)
-	id := makeId()
-	req := logAnalyticsEventRequest{Type: "wf_api_log_analytics_event_request", Id: id, Content: message, ContentType: "default", Category: category, DeviceUri: sourceUri}
-	call := wfInst.sendAndReceiveRequest(req, id)
-	res := LogAnalyticsEventResponse{}
-	json.Unmarshal(call.EventWrapper.Msg, &res)
-	return res
+// Same as ListenCtx, but returns the whole SpeechEvent, with the recognized text, the audio,
+// the language and the source of the speech. Several listens, i.e. on different devices, can
+// be in progress at the same time. Waits up to timeout seconds for the user to speak.
+func (wfInst *workflowInstance) ListenSpeech(ctx context.Context, sourceUri string, phrases []string, transcribe bool, alt_lang Language, timeout int) (SpeechEvent, error) {
+	wfInst.Logger.Debug("listening on ", sourceUri)
+	// the speech event carries the request id of its listen request, the id of the call
+	return wfInst.listenCtx(ctx, makeTargetMap(sourceUri), phrases, transcribe, timeout, string(alt_lang))
 }
 
-// Sets a variable with the corresponding name and value. Scope of
-// the variable is from start to end of a workflow.  Note that you
-// can only set values of type string. Returns a SetVarResponse.
-func (wfInst *workflowInstance) SetVar(name string, value string) SetVarResponse {
-	log.Debug("setting variable with name ", name, " and value ", value)
-	id := makeId()
-	req := setVarRequest{Type: "wf_api_set_var_request", Id: id, Name: name, Value: value}
-	call := wfInst.sendAndReceiveRequest(req, id)
-	res := SetVarResponse{}
-	json.Unmarshal(call.EventWrapper.Msg, &res)
-	return res
+// how long to wait for the answer to a request the server answers itself after timeout
+// seconds, i.e. the speech event of a listen
+func responseTimeout(timeout int) time.Duration {
+	wait := time.Duration(timeout)*time.Second + 10*time.Second
+	if wait < 60*time.Second {
+		wait = 60 * time.Second
+	}
+	return wait
 }
 
-// Unsets the value of a variable. Returns an UnsetVarResponse.
-func (wfInst *workflowInstance) UnsetVar(name string) UnsetVarResponse {
-	log.Debug("unsetting variable with name ", name)
-	id := makeId()
-	req := unsetVarRequest{Type: "wf_api_unset_var_request", Id: id, Name: name}
-	call := wfInst.sendAndReceiveRequest(req, id)
-	res := UnsetVarResponse{}
-	json.Unmarshal(call.EventWrapper.Msg, &res)
+// Translates text from one language to another. Returns the translated text in the specified language as a string.
+func (wfInst *workflowInstance) Translate(sourceUri string, text string, from Language, to Language) string {
+	res, _ := wfInst.TranslateCtx(wfInst.Ctx, sourceUri, text, from, to)
 	return res
 }
 
+// Same as Translate, but bounded by ctx. Returns an error if the request fails.
+func (wfInst *workflowInstance) TranslateCtx(ctx context.Context, sourceUri string, text string, from Language, to Language) (string, error) {
+	wfInst.Logger.Debug("translating ", text)
+	res, err := wfInst.translateCtx(ctx, text, from, to)
+	return res.Text, err
+}
+
 // Retrieves a variable that was set either during workflow registration
 // or through the set_var() function.  The variable can be retrieved anywhere
 // within the workflow, but is erased after the workflow terminates. Returns the
 // requested variable's value as a string.
 func (wfInst *workflowInstance) GetVar(name string, defaultValue string) string {
-	log.Debug("getting variable with name ", name, " and default value ", defaultValue)
-	id := makeId()
-	req := getVarRequest{Type: "wf_api_get_var_request", Id: id, Name: name}
-	call := wfInst.sendAndReceiveRequest(req, id)
-	res := GetVarResponse{}
-	json.Unmarshal(call.EventWrapper.Msg, &res)
+	res, _ := wfInst.GetVarCtx(wfInst.Ctx, name, defaultValue)
+	return res
+}
+
+// Same as GetVar, but bounded by ctx. Returns the default value and an error if the request fails.
+func (wfInst *workflowInstance) GetVarCtx(ctx context.Context, name string, defaultValue string) (string, error) {
+	wfInst.Logger.Debug("getting variable with name ", name, " and default value ", defaultValue)
+	res, err := wfInst.getVarCtx(ctx, name)
 	if res.Value != "" {
-		return res.Value
+		return res.Value, err
 	}
-	return defaultValue
+	return defaultValue, err
 }
 
 // Retrieves a variable that was set either during workflow registration
@@ -505,300 +532,401 @@ func (wfInst *workflowInstance) GetVar(name string, defaultValue string) string
 // within the workflow, but is erased after the workflow terminates. Returns the requested
 // variable's value as an integer.
 func (wfInst *workflowInstance) GetNumberVar(name string, defaultValue int) int {
-	numVar, err := strconv.Atoi(wfInst.GetVar(name, strconv.FormatInt(int64(defaultValue), 10)))
-	log.Error(err)
+	numVar, err := wfInst.GetNumberVarCtx(wfInst.Ctx, name, defaultValue)
+	if err != nil {
+		wfInst.Logger.Error(err)
+	}
 	return numVar
 }
 
+// Same as GetNumberVar, but bounded by ctx. Returns an error if the request fails or the
+// variable is not an integer.
+func (wfInst *workflowInstance) GetNumberVarCtx(ctx context.Context, name string, defaultValue int) (int, error) {
+	value, err := wfInst.GetVarCtx(ctx, name, strconv.FormatInt(int64(defaultValue), 10))
+	if err != nil {
+		return defaultValue, err
+	}
+	return strconv.Atoi(value)
+}
+
 // Plays a custom audio file that was uploaded by the user. Returns the correlation ID retrieved
 // from the PlayResponse as a string.
 func (wfInst *workflowInstance) Play(sourceUri string, filename string) string {
-	log.Debug("playing file ", filename, " to ", sourceUri)
-	id := makeId()
-	target := makeTargetMap(sourceUri)
-	req := playRequest{Type: "wf_api_play_request", Id: id, Target: target, Filename: filename}
-	call := wfInst.sendAndReceiveRequest(req, id)
-	res := PlayResponse{}
-	json.Unmarshal(call.EventWrapper.Msg, &res)
-	return res.CorrelationId
+	res, _ := wfInst.PlayCtx(wfInst.Ctx, sourceUri, filename)
+	return res
+}
+
+// Same as Play, but bounded by ctx. Returns an error if the request fails.
+func (wfInst *workflowInstance) PlayCtx(ctx context.Context, sourceUri string, filename string) (string, error) {
+	wfInst.Logger.Debug("playing file ", filename, " to ", sourceUri)
+	res, err := wfInst.playCtx(ctx, makeTargetMap(sourceUri), filename)
+	return res.CorrelationId, err
 }
 
 // Plays a custom audio file that was uploaded by the user.
 // Waits until the audio file has finished playing before continuing through
 // the workflow. Returns the correlation ID retrieved from the PlayResponse as a string.
 func (wfInst *workflowInstance) PlayAndWait(sourceUri string, filename string) string {
-	log.Debug("playing file ", filename, " to ", sourceUri)
-	id := makeId()
-	target := makeTargetMap(sourceUri)
-	req := playRequest{Type: "wf_api_play_request", Id: id, Target: target, Filename: filename}
-	call := wfInst.sendAndReceiveRequestWait(req, id)
-	res := PlayResponse{}
-	json.Unmarshal(call.EventWrapper.Msg, &res)
-	return res.CorrelationId
-}
-
-// Stops a playback request on the device. Returns the StopPlaybackResponse.
-func (wfInst *workflowInstance) StopPlayback(sourceUri string, ids []string) StopPlaybackResponse {
-	log.Debug("stopping playback for ", ids)
-	id := makeId()
-	target := makeTargetMap(sourceUri)
-	req := stopPlaybackRequest{Type: "wf_api_stop_playback_request", Id: id, Target: target, Ids: ids}
-	call := wfInst.sendAndReceiveRequest(req, id)
-	res := StopPlaybackResponse{}
-	json.Unmarshal(call.EventWrapper.Msg, &res)
+	res, _ := wfInst.PlayAndWaitCtx(wfInst.Ctx, sourceUri, filename)
 	return res
 }
 
+// Same as PlayAndWait, but bounded by ctx. Returns an error if the request fails.
+func (wfInst *workflowInstance) PlayAndWaitCtx(ctx context.Context, sourceUri string, filename string) (string, error) {
+	wfInst.Logger.Debug("playing file ", filename, " to ", sourceUri)
+	res, err := wfInst.playAndWaitCtx(ctx, makeTargetMap(sourceUri), filename)
+	return res.CorrelationId, err
+}
+
 // Retrieves the number of messages in device's inbox. Returns the number
 // of unread messages in the device's inbox as an integer.
 func (wfInst *workflowInstance) GetUnreadInboxSize(sourceUri string) int {
-	log.Debug("playing unread inbox messages for ", sourceUri)
-	id := makeId()
-	target := makeTargetMap(sourceUri)
-	req := inboxCountRequest{Type: "wf_api_inbox_count_request", Id: id, Target: target}
-	call := wfInst.sendAndReceiveRequest(req, id)
-	res := InboxCountResponse{}
-	json.Unmarshal(call.EventWrapper.Msg, &res)
-	count, err := strconv.Atoi(res.Count)
-	fmt.Println("error", err)
+	count, err := wfInst.GetUnreadInboxSizeCtx(wfInst.Ctx, sourceUri)
+	if err != nil {
+		wfInst.Logger.Error(err)
+	}
 	return count
 }
 
-// Play a targeted device's inbox messages. Returns the PlayInboxMessagesResponse.
-func (wfInst *workflowInstance) PlayUnreadInboxMessages(sourceUri string) PlayInboxMessagesResponse {
-	log.Debug("playing unread inbox messages for ", sourceUri)
-	id := makeId()
-	target := makeTargetMap(sourceUri)
-	req := playInboxMessagesRequest{Type: "wf_api_play_inbox_messages_request", Id: id, Target: target}
-	call := wfInst.sendAndReceiveRequest(req, id)
-	res := PlayInboxMessagesResponse{}
-	json.Unmarshal(call.EventWrapper.Msg, &res)
-	return res
+// Same as GetUnreadInboxSize, but bounded by ctx. Returns an error if the request fails.
+func (wfInst *workflowInstance) GetUnreadInboxSizeCtx(ctx context.Context, sourceUri string) (int, error) {
+	wfInst.Logger.Debug("retrieving unread inbox size for ", sourceUri)
+	res, err := wfInst.inboxCountCtx(ctx, makeTargetMap(sourceUri))
+	if err != nil {
+		return 0, err
+	}
+	return strconv.Atoi(res.Count)
 }
 
-func (wfInst *workflowInstance) setHomeChannelState(sourceUri string, enabled bool) SetHomeChannelStateResponse {
-	log.Debug("setting home channel for ", sourceUri, " with state ", enabled)
-	id := makeId()
-	target := makeTargetMap(sourceUri)
-	req := setHomeChannelStateRequest{Type: "wf_api_set_home_channel_state_request", Id: id, Target: target, Enabled: enabled}
-	call := wfInst.sendAndReceiveRequest(req, id)
-	res := SetHomeChannelStateResponse{}
-	json.Unmarshal(call.EventWrapper.Msg, &res)
-	return res
+func (wfInst *workflowInstance) setHomeChannelState(ctx context.Context, sourceUri string, enabled bool) (SetHomeChannelStateResponse, error) {
+	wfInst.Logger.Debug("setting home channel for ", sourceUri, " with state ", enabled)
+	return wfInst.setHomeChannelStateCtx(ctx, makeTargetMap(sourceUri), enabled)
 }
 
 // Enables the home channel on the device. Returns the SetHomeChannelStateResponse.
 func (wfInst *workflowInstance) EnableHomeChannel(sourceUri string) SetHomeChannelStateResponse {
-	return wfInst.setHomeChannelState(sourceUri, true)
+	res, _ := wfInst.EnableHomeChannelCtx(wfInst.Ctx, sourceUri)
+	return res
+}
+
+// Same as EnableHomeChannel, but bounded by ctx. Returns an error if the request fails.
+func (wfInst *workflowInstance) EnableHomeChannelCtx(ctx context.Context, sourceUri string) (SetHomeChannelStateResponse, error) {
+	return wfInst.setHomeChannelState(ctx, sourceUri, true)
 }
 
 // Disables the home channel on the device. Returns the SetHomeChannelStateResponse.
 func (wfInst *workflowInstance) DisableHomeChannel(sourceUri string) SetHomeChannelStateResponse {
-	return wfInst.setHomeChannelState(sourceUri, false)
+	res, _ := wfInst.DisableHomeChannelCtx(wfInst.Ctx, sourceUri)
+	return res
 }
 
-func (wfInst *workflowInstance) setLeds(sourceUri string, effect LedEffect, args LedInfo) SetLedResponse {
-	log.Debug("setting leds ", effect, " with args ", args)
-	id := makeId()
-	target := makeTargetMap(sourceUri)
-	req := setLedRequest{Type: "wf_api_set_led_request", Id: id, Target: target, Effect: effect, Args: args}
-	call := wfInst.sendAndReceiveRequest(req, id)
-	res := SetLedResponse{}
-	json.Unmarshal(call.EventWrapper.Msg, &res)
-	return res
+// Same as DisableHomeChannel, but bounded by ctx. Returns an error if the request fails.
+func (wfInst *workflowInstance) DisableHomeChannelCtx(ctx context.Context, sourceUri string) (SetHomeChannelStateResponse, error) {
+	return wfInst.setHomeChannelState(ctx, sourceUri, false)
+}
+
+func (wfInst *workflowInstance) setLeds(ctx context.Context, sourceUri string, effect LedEffect, args LedInfo) (SetLedResponse, error) {
+	wfInst.Logger.Debug("setting leds ", effect, " with args ", args)
+	return wfInst.setLedCtx(ctx, makeTargetMap(sourceUri), effect, args)
 }
 
 // Switches on an LED at a particules index to a specified color. Returns a SetLedResponse.
 func (wfInst *workflowInstance) SwitchLedOn(sourceUri string, led int, color string) SetLedResponse {
-	return wfInst.setLeds(sourceUri, LED_STATIC, LedInfo{Colors: setLedColors(strconv.FormatInt(int64(led), 10), color)})
+	res, _ := wfInst.SwitchLedOnCtx(wfInst.Ctx, sourceUri, led, color)
+	return res
+}
+
+// Same as SwitchLedOn, but bounded by ctx. Returns an error if the request fails.
+func (wfInst *workflowInstance) SwitchLedOnCtx(ctx context.Context, sourceUri string, led int, color string) (SetLedResponse, error) {
+	return wfInst.setLeds(ctx, sourceUri, LED_STATIC, LedInfo{Colors: setLedColors(strconv.FormatInt(int64(led), 10), color)})
 }
 
 // Switches all the LEDs on a device on to a specified color. Returns a SetLedResponse.
 func (wfInst *workflowInstance) SwitchAllLedOn(sourceUri string, color string) SetLedResponse {
-	return wfInst.setLeds(sourceUri, LED_STATIC, LedInfo{Colors: LedColors{Ring: color}})
+	res, _ := wfInst.SwitchAllLedOnCtx(wfInst.Ctx, sourceUri, color)
+	return res
+}
+
+// Same as SwitchAllLedOn, but bounded by ctx. Returns an error if the request fails.
+func (wfInst *workflowInstance) SwitchAllLedOnCtx(ctx context.Context, sourceUri string, color string) (SetLedResponse, error) {
+	return wfInst.setLeds(ctx, sourceUri, LED_STATIC, LedInfo{Colors: LedColors{Ring: color}})
 }
 
 // Swithes all of the LEDs on a device off. Returns a SetLedResponse.
 func (wfInst *workflowInstance) SwitchAllLedOff(sourceUri string) SetLedResponse {
-	return wfInst.setLeds(sourceUri, LED_OFF, LedInfo{})
+	res, _ := wfInst.SwitchAllLedOffCtx(wfInst.Ctx, sourceUri)
+	return res
+}
+
+// Same as SwitchAllLedOff, but bounded by ctx. Returns an error if the request fails.
+func (wfInst *workflowInstance) SwitchAllLedOffCtx(ctx context.Context, sourceUri string) (SetLedResponse, error) {
+	return wfInst.setLeds(ctx, sourceUri, LED_OFF, LedInfo{})
 }
 
 // Switches all the LEDs on to a configured rainbow pattern and rotates the rainbow
 // a specified number of times. Returns a SetLedResponse.
 func (wfInst *workflowInstance) Rainbow(sourceUri string, rotations int64) SetLedResponse {
-	return wfInst.setLeds(sourceUri, LED_RAINBOW, LedInfo{Rotations: rotations})
+	res, _ := wfInst.RainbowCtx(wfInst.Ctx, sourceUri, rotations)
+	return res
+}
+
+// Same as Rainbow, but bounded by ctx. Returns an error if the request fails.
+func (wfInst *workflowInstance) RainbowCtx(ctx context.Context, sourceUri string, rotations int64) (SetLedResponse, error) {
+	return wfInst.setLeds(ctx, sourceUri, LED_RAINBOW, LedInfo{Rotations: rotations})
 }
 
 // Switches all of the LEDs on a device to a certain color and rotates them a specified number
 // of times. Returns a SetLedResponse.
 func (wfInst *workflowInstance) Rotate(sourceUri string, color string, rotations int64) SetLedResponse {
-	return wfInst.setLeds(sourceUri, LED_ROTATE, LedInfo{Rotations: rotations, Colors: LedColors{Led1: color}})
+	res, _ := wfInst.RotateCtx(wfInst.Ctx, sourceUri, color, rotations)
+	return res
+}
+
+// Same as Rotate, but bounded by ctx. Returns an error if the request fails.
+func (wfInst *workflowInstance) RotateCtx(ctx context.Context, sourceUri string, color string, rotations int64) (SetLedResponse, error) {
+	return wfInst.setLeds(ctx, sourceUri, LED_ROTATE, LedInfo{Rotations: rotations, Colors: LedColors{Led1: color}})
 }
 
 // Switches all of the LEDs on a device to a certain color and flashes them
 // a specified number of times. Returns a SetLedResponse.
 func (wfInst *workflowInstance) Flash(sourceUri string, color string, count int64) SetLedResponse {
-	return wfInst.setLeds(sourceUri, LED_FLASH, LedInfo{Count: count, Colors: LedColors{Ring: color}})
+	res, _ := wfInst.FlashCtx(wfInst.Ctx, sourceUri, color, count)
+	return res
+}
+
+// Same as Flash, but bounded by ctx. Returns an error if the request fails.
+func (wfInst *workflowInstance) FlashCtx(ctx context.Context, sourceUri string, color string, count int64) (SetLedResponse, error) {
+	return wfInst.setLeds(ctx, sourceUri, LED_FLASH, LedInfo{Count: count, Colors: LedColors{Ring: color}})
 }
 
 // Switches all of the LEDs on a device to a certain color and creates a 'breathing' effect,
 // where the LEDs will slowly light up a specified number of times. Returns a SetLedResponse.
 func (wfInst *workflowInstance) Breathe(sourceUri string, color string, count int64) SetLedResponse {
-	return wfInst.setLeds(sourceUri, LED_BREATHE, LedInfo{Count: count, Colors: LedColors{Ring: color}})
-}
-
-// Makes the device vibrate in a particular pattern.  You can specify
-// how many vibrations you would like, the duration of each vibration in
-// milliseconds, and how long you would like the pauses between each vibration to last
-// in milliseconds. Returns a VibrateResponse.
-func (wfInst *workflowInstance) Vibrate(sourceUri string, pattern []int64) VibrateResponse {
-	log.Debug("vibrating with pattern ", pattern)
-	id := makeId()
-	target := makeTargetMap(sourceUri)
-	req := vibrateRequest{Type: "wf_api_vibrate_request", Id: id, Target: target, Pattern: pattern}
-	call := wfInst.sendAndReceiveRequest(req, id)
-	res := VibrateResponse{}
-	json.Unmarshal(call.EventWrapper.Msg, &res)
+	res, _ := wfInst.BreatheCtx(wfInst.Ctx, sourceUri, color, count)
 	return res
 }
 
-func (wfInst *workflowInstance) sendNotification(target string, originator string, itype string, name string, text string, pushOptions NotificationOptions) SendNotificationResponse {
-	log.Debug("sending a notification of type ", itype)
-	id := makeId()
+// Same as Breathe, but bounded by ctx. Returns an error if the request fails.
+func (wfInst *workflowInstance) BreatheCtx(ctx context.Context, sourceUri string, color string, count int64) (SetLedResponse, error) {
+	return wfInst.setLeds(ctx, sourceUri, LED_BREATHE, LedInfo{Count: count, Colors: LedColors{Ring: color}})
+}
+
+func (wfInst *workflowInstance) sendNotification(ctx context.Context, target string, originator string, itype string, name string, text string, pushOptions NotificationOptions) (SendNotificationResponse, error) {
+	wfInst.Logger.Debug("sending a notification of type ", itype)
 	targetMap := makeTargetMap(target)
-	req := sendNotificationRequest{Type: "wf_api_notification_request", Id: id, Target: targetMap, Originator: originator, IType: itype, Name: name, Text: text, ITarget: targetMap, PushOptions: pushOptions}
-	call := wfInst.sendAndReceiveRequest(req, id)
-	res := SendNotificationResponse{}
-	json.Unmarshal(call.EventWrapper.Msg, &res)
-	return res
+	return wfInst.sendNotificationCtx(ctx, targetMap, originator, itype, name, text, targetMap, pushOptions)
 }
 
 // Sends out a broadcasted message to a group of devices.  The message is played out on
 // all devices, as well as sent to the Relay Dash. Returns a SendNotificationResponse.
 func (wfInst *workflowInstance) Broadcast(target string, originator string, name string, text string, pushOptions NotificationOptions) SendNotificationResponse {
-	return wfInst.sendNotification(target, originator, "broadcast", name, text, pushOptions)
+	res, _ := wfInst.BroadcastCtx(wfInst.Ctx, target, originator, name, text, pushOptions)
+	return res
+}
+
+// Same as Broadcast, but bounded by ctx. Returns an error if the request fails.
+func (wfInst *workflowInstance) BroadcastCtx(ctx context.Context, target string, originator string, name string, text string, pushOptions NotificationOptions) (SendNotificationResponse, error) {
+	return wfInst.sendNotification(ctx, target, originator, "broadcast", name, text, pushOptions)
 }
 
 // Cancels the broadcsat that was sent to a group of devices. Returns a SendNotificationResponse.
 func (wfInst *workflowInstance) CancelBroadcast(target string, name string) SendNotificationResponse {
+	res, _ := wfInst.CancelBroadcastCtx(wfInst.Ctx, target, name)
+	return res
+}
+
+// Same as CancelBroadcast, but bounded by ctx. Returns an error if the request fails.
+func (wfInst *workflowInstance) CancelBroadcastCtx(ctx context.Context, target string, name string) (SendNotificationResponse, error) {
 	var pushOptions NotificationOptions
-	return wfInst.sendNotification(target, "", "cancel", name, "", pushOptions)
+	return wfInst.sendNotification(ctx, target, "", "cancel", name, "", pushOptions)
 }
 
 // Sends out an alert to the specified group of devices and the Relay Dash. Returns a SendNotificationResponse.
 func (wfInst *workflowInstance) Alert(target string, originator string, name string, text string, pushOptions NotificationOptions) SendNotificationResponse {
-	return wfInst.sendNotification(target, originator, "alert", name, text, pushOptions)
+	res, _ := wfInst.AlertCtx(wfInst.Ctx, target, originator, name, text, pushOptions)
+	return res
+}
+
+// Same as Alert, but bounded by ctx. Returns an error if the request fails.
+func (wfInst *workflowInstance) AlertCtx(ctx context.Context, target string, originator string, name string, text string, pushOptions NotificationOptions) (SendNotificationResponse, error) {
+	return wfInst.sendNotification(ctx, target, originator, "alert", name, text, pushOptions)
 }
 
 // Cancels an alert that was sent to a group of devices.  Particularly useful if you would like to cancel the alert
 // on all devices after one device has acknowledged the alert. Returns a SendNotificationResponse.
 func (wfInst *workflowInstance) CancelAlert(target string, name string) SendNotificationResponse {
+	res, _ := wfInst.CancelAlertCtx(wfInst.Ctx, target, name)
+	return res
+}
+
+// Same as CancelAlert, but bounded by ctx. Returns an error if the request fails.
+func (wfInst *workflowInstance) CancelAlertCtx(ctx context.Context, target string, name string) (SendNotificationResponse, error) {
 	var pushOptions NotificationOptions
-	return wfInst.sendNotification(target, "", "cancel", name, "", pushOptions)
+	return wfInst.sendNotification(ctx, target, "", "cancel", name, "", pushOptions)
 }
 
-func (wfInst *workflowInstance) getDeviceInfo(sourceUri string, query DeviceInfoQuery, refresh bool) GetDeviceInfoResponse {
-	log.Debug("getting device info with query ", query, " refresh ", refresh)
-	id := makeId()
-	target := makeTargetMap(sourceUri)
-	req := getDeviceInfoRequest{Type: "wf_api_get_device_info_request", Id: id, Target: target, Query: query, Refresh: refresh}
-	call := wfInst.sendAndReceiveRequest(req, id)
-	res := GetDeviceInfoResponse{}
-	json.Unmarshal(call.EventWrapper.Msg, &res)
-	return res
+func (wfInst *workflowInstance) getDeviceInfo(ctx context.Context, sourceUri string, query DeviceInfoQuery, refresh bool) (GetDeviceInfoResponse, error) {
+	wfInst.Logger.Debug("getting device info with query ", query, " refresh ", refresh)
+	return wfInst.getDeviceInfoCtx(ctx, makeTargetMap(sourceUri), query, refresh)
 }
 
 // Returns the name of a targeted device as a string.
 func (wfInst *workflowInstance) GetDeviceName(sourceUri string, refresh bool) string {
-	resp := wfInst.getDeviceInfo(sourceUri, DEVICE_INFO_QUERY_NAME, refresh)
-	log.Debug("device info name ", resp.Name)
-	return resp.Name
+	res, _ := wfInst.GetDeviceNameCtx(wfInst.Ctx, sourceUri, refresh)
+	return res
+}
+
+// Same as GetDeviceName, but bounded by ctx. Returns an error if the request fails.
+func (wfInst *workflowInstance) GetDeviceNameCtx(ctx context.Context, sourceUri string, refresh bool) (string, error) {
+	resp, err := wfInst.getDeviceInfo(ctx, sourceUri, DEVICE_INFO_QUERY_NAME, refresh)
+	wfInst.Logger.Debug("device info name ", resp.Name)
+	return resp.Name, err
 }
 
 // Returns the ID of the targeted device as a string.
 func (wfInst *workflowInstance) GetDeviceId(sourceUri string, refresh bool) string {
-	resp := wfInst.getDeviceInfo(sourceUri, DEVICE_INFO_QUERY_ID, refresh)
-	log.Debug("device info id ", resp.Id)
-	return resp.Id
+	res, _ := wfInst.GetDeviceIdCtx(wfInst.Ctx, sourceUri, refresh)
+	return res
+}
+
+// Same as GetDeviceId, but bounded by ctx. Returns an error if the request fails.
+func (wfInst *workflowInstance) GetDeviceIdCtx(ctx context.Context, sourceUri string, refresh bool) (string, error) {
+	resp, err := wfInst.getDeviceInfo(ctx, sourceUri, DEVICE_INFO_QUERY_ID, refresh)
+	wfInst.Logger.Debug("device info id ", resp.Id)
+	return resp.Id, err
 }
 
 // Returns the location of a targeted device as a string.
 func (wfInst *workflowInstance) GetDeviceLocation(sourceUri string, refresh bool) string {
-	resp := wfInst.getDeviceInfo(sourceUri, DEVICE_INFO_QUERY_ADDRESS, refresh)
-	log.Debug("device info address ", resp.Address)
-	return resp.Address
+	res, _ := wfInst.GetDeviceLocationCtx(wfInst.Ctx, sourceUri, refresh)
+	return res
+}
+
+// Same as GetDeviceLocation, but bounded by ctx. Returns an error if the request fails.
+func (wfInst *workflowInstance) GetDeviceLocationCtx(ctx context.Context, sourceUri string, refresh bool) (string, error) {
+	resp, err := wfInst.getDeviceInfo(ctx, sourceUri, DEVICE_INFO_QUERY_ADDRESS, refresh)
+	wfInst.Logger.Debug("device info address ", resp.Address)
+	return resp.Address, err
 }
 
 // Returns the address of a targeted device as a string.
 func (wfInst *workflowInstance) GetDeviceAddress(sourceUri string, refresh bool) string {
-	return wfInst.GetDeviceLocation(sourceUri, refresh)
+	res, _ := wfInst.GetDeviceAddressCtx(wfInst.Ctx, sourceUri, refresh)
+	return res
+}
+
+// Same as GetDeviceAddress, but bounded by ctx. Returns an error if the request fails.
+func (wfInst *workflowInstance) GetDeviceAddressCtx(ctx context.Context, sourceUri string, refresh bool) (string, error) {
+	return wfInst.GetDeviceLocationCtx(ctx, sourceUri, refresh)
 }
 
 // Retrieves the coordinates of the device's location. Returns a float64 array containing the coordinates of
 // the device.
 func (wfInst *workflowInstance) GetDeviceCoordinates(sourceUri string, refresh bool) []float64 {
-	resp := wfInst.getDeviceInfo(sourceUri, DEVICE_INFO_QUERY_LATLONG, refresh)
-	log.Debug("device info latlong ", resp.LatLong)
-	return resp.LatLong
+	res, _ := wfInst.GetDeviceCoordinatesCtx(wfInst.Ctx, sourceUri, refresh)
+	return res
+}
+
+// Same as GetDeviceCoordinates, but bounded by ctx. Returns an error if the request fails.
+func (wfInst *workflowInstance) GetDeviceCoordinatesCtx(ctx context.Context, sourceUri string, refresh bool) ([]float64, error) {
+	resp, err := wfInst.getDeviceInfo(ctx, sourceUri, DEVICE_INFO_QUERY_LATLONG, refresh)
+	wfInst.Logger.Debug("device info latlong ", resp.LatLong)
+	return resp.LatLong, err
 }
 
 // Returns the latitude and longitude coordinates of a targeted device. Returns a float64 array containing thecoordinates
 // of the device.
 func (wfInst *workflowInstance) GetDeviceLatLong(sourceUri string, refresh bool) []float64 {
-	return wfInst.GetDeviceCoordinates(sourceUri, refresh)
+	res, _ := wfInst.GetDeviceLatLongCtx(wfInst.Ctx, sourceUri, refresh)
+	return res
+}
+
+// Same as GetDeviceLatLong, but bounded by ctx. Returns an error if the request fails.
+func (wfInst *workflowInstance) GetDeviceLatLongCtx(ctx context.Context, sourceUri string, refresh bool) ([]float64, error) {
+	return wfInst.GetDeviceCoordinatesCtx(ctx, sourceUri, refresh)
 }
 
 // Returns the indoor location of a targeted device as a string.
 func (wfInst *workflowInstance) GetDeviceIndoorLocation(sourceUri string, refresh bool) string {
-	resp := wfInst.getDeviceInfo(sourceUri, DEVICE_INFO_QUERY_INDOOR_LOCATION, refresh)
-	log.Debug("device info indoor location ", resp.IndoorLocation)
-	return resp.IndoorLocation
+	res, _ := wfInst.GetDeviceIndoorLocationCtx(wfInst.Ctx, sourceUri, refresh)
+	return res
+}
+
+// Same as GetDeviceIndoorLocation, but bounded by ctx. Returns an error if the request fails.
+func (wfInst *workflowInstance) GetDeviceIndoorLocationCtx(ctx context.Context, sourceUri string, refresh bool) (string, error) {
+	resp, err := wfInst.getDeviceInfo(ctx, sourceUri, DEVICE_INFO_QUERY_INDOOR_LOCATION, refresh)
+	wfInst.Logger.Debug("device info indoor location ", resp.IndoorLocation)
+	return resp.IndoorLocation, err
 }
 
 // Returns the battery of a targeted device as a string.
 func (wfInst *workflowInstance) GetDeviceBattery(sourceUri string, refresh bool) uint64 {
-	resp := wfInst.getDeviceInfo(sourceUri, DEVICE_INFO_QUERY_BATTERY, refresh)
-	log.Debug("device info battery ", resp.Battery)
-	return resp.Battery
+	res, _ := wfInst.GetDeviceBatteryCtx(wfInst.Ctx, sourceUri, refresh)
+	return res
+}
+
+// Same as GetDeviceBattery, but bounded by ctx. Returns an error if the request fails.
+func (wfInst *workflowInstance) GetDeviceBatteryCtx(ctx context.Context, sourceUri string, refresh bool) (uint64, error) {
+	resp, err := wfInst.getDeviceInfo(ctx, sourceUri, DEVICE_INFO_QUERY_BATTERY, refresh)
+	wfInst.Logger.Debug("device info battery ", resp.Battery)
+	return resp.Battery, err
 }
 
 // Returns the device type of a targeted device, i.e. gen 2, gen 3, etc. as a string.
 func (wfInst *workflowInstance) GetDeviceType(sourceUri string, refresh bool) string {
-	resp := wfInst.getDeviceInfo(sourceUri, DEVICE_INFO_QUERY_TYPE, refresh)
-	log.Debug("device info type ", resp.Type)
-	return resp.Type
+	res, _ := wfInst.GetDeviceTypeCtx(wfInst.Ctx, sourceUri, refresh)
+	return res
+}
+
+// Same as GetDeviceType, but bounded by ctx. Returns an error if the request fails.
+func (wfInst *workflowInstance) GetDeviceTypeCtx(ctx context.Context, sourceUri string, refresh bool) (string, error) {
+	resp, err := wfInst.getDeviceInfo(ctx, sourceUri, DEVICE_INFO_QUERY_TYPE, refresh)
+	wfInst.Logger.Debug("device info type ", resp.Type)
+	return resp.Type, err
 }
 
 // Returns the user profile of a targeted device as a string.
 func (wfInst *workflowInstance) GetUserProfile(sourceUri string, refresh bool) string {
-	resp := wfInst.getDeviceInfo(sourceUri, DEVICE_INFO_QUERY_USERNAME, refresh)
-	log.Debug("device info username ", resp.Username)
-	return resp.Username
+	res, _ := wfInst.GetUserProfileCtx(wfInst.Ctx, sourceUri, refresh)
+	return res
+}
+
+// Same as GetUserProfile, but bounded by ctx. Returns an error if the request fails.
+func (wfInst *workflowInstance) GetUserProfileCtx(ctx context.Context, sourceUri string, refresh bool) (string, error) {
+	resp, err := wfInst.getDeviceInfo(ctx, sourceUri, DEVICE_INFO_QUERY_USERNAME, refresh)
+	wfInst.Logger.Debug("device info username ", resp.Username)
+	return resp.Username, err
 }
 
 // Returns whether the location services on a device are enabled as a boolean.
 func (wfInst *workflowInstance) GetDeviceLocationEnabled(sourceUri string, refresh bool) bool {
-	resp := wfInst.getDeviceInfo(sourceUri, DEVICE_INFO_QUERY_LOCATION_ENABLED, refresh)
-	log.Debug("device info location enabled ", resp.LocationEnabled)
-	return resp.LocationEnabled
-}
-
-func (wfInst *workflowInstance) setDeviceInfo(sourceUri string, field SetDeviceInfoType, value string) SetDeviceInfoResponse {
-	log.Debug("setting device info field ", field, " to ", value)
-	id := makeId()
-	target := makeTargetMap(sourceUri)
-	req := setDeviceInfoRequest{Type: "wf_api_set_device_info_request", Id: id, Target: target, Field: field, Value: value}
-	call := wfInst.sendAndReceiveRequest(req, id)
-	res := SetDeviceInfoResponse{}
-	json.Unmarshal(call.EventWrapper.Msg, &res)
+	res, _ := wfInst.GetDeviceLocationEnabledCtx(wfInst.Ctx, sourceUri, refresh)
 	return res
 }
 
+// Same as GetDeviceLocationEnabled, but bounded by ctx. Returns an error if the request fails.
+func (wfInst *workflowInstance) GetDeviceLocationEnabledCtx(ctx context.Context, sourceUri string, refresh bool) (bool, error) {
+	resp, err := wfInst.getDeviceInfo(ctx, sourceUri, DEVICE_INFO_QUERY_LOCATION_ENABLED, refresh)
+	wfInst.Logger.Debug("device info location enabled ", resp.LocationEnabled)
+	return resp.LocationEnabled, err
+}
+
+func (wfInst *workflowInstance) setDeviceInfo(ctx context.Context, sourceUri string, field SetDeviceInfoType, value string) (SetDeviceInfoResponse, error) {
+	wfInst.Logger.Debug("setting device info field ", field, " to ", value)
+	return wfInst.setDeviceInfoCtx(ctx, makeTargetMap(sourceUri), field, value)
+}
+
 // Sets the name of a targeted device and updates it on the Relay Dash.
 // The name remains updated until it is set again via a workflow or updated manually
 // on the Relay Dash.  Returns a SendNotificationResponse.
 func (wfInst *workflowInstance) SetDeviceName(sourceUri string, name string) SetDeviceInfoResponse {
-	return wfInst.setDeviceInfo(sourceUri, SET_DEVICE_INFO_LABEL, name)
+	res, _ := wfInst.SetDeviceNameCtx(wfInst.Ctx, sourceUri, name)
+	return res
+}
+
+// Same as SetDeviceName, but bounded by ctx. Returns an error if the request fails.
+func (wfInst *workflowInstance) SetDeviceNameCtx(ctx context.Context, sourceUri string, name string) (SetDeviceInfoResponse, error) {
+	return wfInst.setDeviceInfo(ctx, sourceUri, SET_DEVICE_INFO_LABEL, name)
 }
 
 // SetDeviceChannel is currently not supported
@@ -810,74 +938,65 @@ func (wfInst *workflowInstance) SetDeviceName(sourceUri string, name string) Set
 // Enables location services on a device.  Location services will remain
 // enabled until they are disabled on the Relay Dash or through a workflow.
 func (wfInst *workflowInstance) EnableLocation(sourceUri string) SetDeviceInfoResponse {
-	return wfInst.setDeviceInfo(sourceUri, SET_DEVICE_INFO_LOCATION_ENABLED, "true")
+	res, _ := wfInst.EnableLocationCtx(wfInst.Ctx, sourceUri)
+	return res
+}
+
+// Same as EnableLocation, but bounded by ctx. Returns an error if the request fails.
+func (wfInst *workflowInstance) EnableLocationCtx(ctx context.Context, sourceUri string) (SetDeviceInfoResponse, error) {
+	return wfInst.setDeviceInfo(ctx, sourceUri, SET_DEVICE_INFO_LOCATION_ENABLED, "true")
 }
 
 // Disables location services on a device.  Location services will remain
 // disabled until they are enabled on the Relay Dash or through a workflow. Returns a SendNotificationResponse.
 func (wfInst *workflowInstance) DisableLocation(sourceUri string) SetDeviceInfoResponse {
-	return wfInst.setDeviceInfo(sourceUri, SET_DEVICE_INFO_LOCATION_ENABLED, "false")
+	res, _ := wfInst.DisableLocationCtx(wfInst.Ctx, sourceUri)
+	return res
+}
+
+// Same as DisableLocation, but bounded by ctx. Returns an error if the request fails.
+func (wfInst *workflowInstance) DisableLocationCtx(ctx context.Context, sourceUri string) (SetDeviceInfoResponse, error) {
+	return wfInst.setDeviceInfo(ctx, sourceUri, SET_DEVICE_INFO_LOCATION_ENABLED, "false")
 }
 
 // Returns the members of a particular group as a string array.
 func (wfInst *workflowInstance) GetGroupMembers(groupUri string) []string {
-	log.Debug("retrieving members of ", groupUri)
-	id := makeId()
-	req := groupQueryRequest{Type: "wf_api_group_query_request", Id: id, GroupUri: groupUri, Query: "list_members"}
-	call := wfInst.sendAndReceiveRequest(req, id)
-	res := GroupQueryResponse{}
-	json.Unmarshal(call.EventWrapper.Msg, &res)
-	return res.MemberUris
+	res, _ := wfInst.GetGroupMembersCtx(wfInst.Ctx, groupUri)
+	return res
+}
+
+// Same as GetGroupMembers, but bounded by ctx. Returns an error if the request fails.
+func (wfInst *workflowInstance) GetGroupMembersCtx(ctx context.Context, groupUri string) ([]string, error) {
+	wfInst.Logger.Debug("retrieving members of ", groupUri)
+	res, err := wfInst.groupQueryCtx(ctx, groupUri, "list_members")
+	return res.MemberUris, err
 }
 
 // Checks whether a device is a member of a particular group. Returns true if the device is a member
 // of the specified group, false otherwise.
 func (wfInst *workflowInstance) IsGroupMember(groupNameUri string, potentialMemberUri string) bool {
+	res, _ := wfInst.IsGroupMemberCtx(wfInst.Ctx, groupNameUri, potentialMemberUri)
+	return res
+}
+
+// Same as IsGroupMember, but bounded by ctx. Returns an error if the request fails.
+func (wfInst *workflowInstance) IsGroupMemberCtx(ctx context.Context, groupNameUri string, potentialMemberUri string) (bool, error) {
 	var groupName string = ParseGroupName(groupNameUri)
 	var deviceName string = ParseDeviceName(potentialMemberUri)
 	var groupUri string = GroupMember(groupName, deviceName)
 
-	fmt.Println("retrieving whether ", deviceName, " is a part of group ", groupName)
-	id := makeId()
-	req := groupQueryRequest{Type: "wf_api_group_query_request", Id: id, GroupUri: groupUri, Query: "is_member"}
-	call := wfInst.sendAndReceiveRequest(req, id)
-	res := GroupQueryResponse{}
-	json.Unmarshal(call.EventWrapper.Msg, &res)
-	return res.IsMember
-}
-
-// Sets the profile of a user by updating the username. Returns a SetUserProfileResponse.
-func (wfInst *workflowInstance) SetUserProfile(sourceUri string, username string, force bool) SetUserProfileResponse {
-	log.Debug("setting user profile to ", username, " force ", force)
-	id := makeId()
-	target := makeTargetMap(sourceUri)
-	req := setUserProfileRequest{Type: "wf_api_set_user_profile_request", Id: id, Target: target, Username: username, Force: force}
-	call := wfInst.sendAndReceiveRequest(req, id)
-	res := SetUserProfileResponse{}
-	json.Unmarshal(call.EventWrapper.Msg, &res)
-	return res
-}
-
-// Sets the channel that a device is on.  This can be used to change the channel of a device during a workflow,
-// where the channel will also be updated on the Relay Dash. Returns a SetChannelResponse.
-func (wfInst *workflowInstance) SetChannel(sourceUri string, channelName string, suppressTTS bool, disableHomeChannel bool) SetChannelResponse {
-	log.Debug("setting channel ", channelName, " suppressTTS ", suppressTTS, " disableHomeChannel ", disableHomeChannel)
-	id := makeId()
-	target := makeTargetMap(sourceUri)
-	req := setChannelRequest{Type: "wf_api_set_channel_request", Id: id, Target: target, ChannelName: channelName, SuppressTTS: suppressTTS, DisableHomeChannel: disableHomeChannel}
-	call := wfInst.sendAndReceiveRequest(req, id)
-	res := SetChannelResponse{}
-	json.Unmarshal(call.EventWrapper.Msg, &res)
-	return res
+	wfInst.Logger.Debug("retrieving whether ", deviceName, " is a part of group ", groupName)
+	res, err := wfInst.groupQueryCtx(ctx, groupUri, "is_member")
+	return res.IsMember, err
 }
 
 // SetDeviceMode is currently not supported.
 
 // func (wfInst *workflowInstance) SetDeviceMode(sourceUri string, mode DeviceMode) SetDeviceModeResponse {
-//     log.Debug("setting device mode ", mode)
-//     id := makeId()
+//     wfInst.Logger.Debug("setting device mode ", mode)
+//     id := wfInst.makeId()
 //     target := makeTargetMap(sourceUri)
-//     req := setDeviceModeRequest{Type: "wf_api_set_device_mode_request", Id: id, Target: target, Mode: mode}
+//     req := setDeviceModeRequest{Type: setDeviceModeRequestType, Id: id, Target: target, Mode: mode}
 //     call := wfInst.sendAndReceiveRequest(req, id)
 //     res := SetDeviceModeResponse{}
 //     json.Unmarshal(call.EventWrapper.Msg, &res)
@@ -888,9 +1007,9 @@ func (wfInst *workflowInstance) SetChannel(sourceUri string, channelName string,
 
 // func (wfInst *workflowInstance) RestartDevice(sourceUri string) DevicePowerOffResponse {
 //     fmt.Println("restarting device")
-//     id := makeId()
+//     id := wfInst.makeId()
 //     target := makeTargetMap(sourceUri)
-//     req := devicePowerOffRequest{Type: "wf_api_device_power_off_request", Id: id, Target: target, Restart: true}
+//     req := devicePowerOffRequest{Type: devicePowerOffRequestType, Id: id, Target: target, Restart: true}
 //     call := wfInst.sendAndReceiveRequest(req, id)
 //     res := DevicePowerOffResponse{}
 //     json.Unmarshal(call.EventWrapper.Msg, &res)
@@ -899,60 +1018,22 @@ func (wfInst *workflowInstance) SetChannel(sourceUri string, channelName string,
 
 // func (wfInst *workflowInstance) PowerDownDevice(sourceUri string) DevicePowerOffResponse {
 //     fmt.Println("powering down device")
-//     id := makeId()
+//     id := wfInst.makeId()
 //     target := makeTargetMap(sourceUri)
-//     req := devicePowerOffRequest{Type: "wf_api_device_power_off_request", Id: id, Target: target, Restart: false}
+//     req := devicePowerOffRequest{Type: devicePowerOffRequestType, Id: id, Target: target, Restart: false}
 //     call := wfInst.sendAndReceiveRequest(req, id)
 //     res := DevicePowerOffResponse{}
 //     json.Unmarshal(call.EventWrapper.Msg, &res)
 //     return res
 // }
 
-// Places a call to another device. Returns a PlaceCallResponse.
-func (wfInst *workflowInstance) PlaceCall(targetUri string, uri string) PlaceCallResponse {
-	log.Debug("placing call to ", targetUri, " with uri ", uri)
-	id := makeId()
-	target := makeTargetMap(targetUri)
-	req := placeCallRequest{Type: "wf_api_call_request", Id: id, Target: target, Uri: uri}
-	call := wfInst.sendAndReceiveRequest(req, id)
-	res := PlaceCallResponse{}
-	json.Unmarshal(call.EventWrapper.Msg, &res)
-	return res
-}
-
-// Answers a call on your device. Returns an AnswerResponse.
-func (wfInst *workflowInstance) AnswerCall(sourceUri string, callId string) AnswerResponse {
-	log.Debug("calling device with call id ", callId)
-	id := makeId()
-	target := makeTargetMap(sourceUri)
-	req := answerRequest{Type: "wf_api_answer_request", Id: id, Target: target, CallId: callId}
-	call := wfInst.sendAndReceiveRequest(req, id)
-	res := AnswerResponse{}
-	json.Unmarshal(call.EventWrapper.Msg, &res)
-	return res
-}
-
-// Ends a call on your device.  Note that target can only have one item. Returns a HangupCallResponse.
-func (wfInst *workflowInstance) HangupCall(targetUri string, callId string) HangupCallResponse {
-	log.Debug("hanging up call with ", callId, " and target uri ", targetUri)
-	id := makeId()
-	target := makeTargetMap(targetUri)
-	req := hangupCallRequest{Type: "wf_api_hangup_request", Id: id, Target: target, CallId: callId}
-	call := wfInst.sendAndReceiveRequest(req, id)
-	res := HangupCallResponse{}
-	json.Unmarshal(call.EventWrapper.Msg, &res)
-	return res
-}
-
 // Terminates a workflow.  This method is usually called
 // after your workflow has completed and you would like to end the
 // workflow by calling end_interaction(), where you can then terminate
 // the workflow.
 func (wfInst *workflowInstance) Terminate() {
-	log.Debug("terminating")
-	id := makeId()
-	req := terminateRequest{Type: "wf_api_terminate_request", Id: id}
-	wfInst.sendRequest(req)
+	wfInst.Logger.Debug("terminating")
+	wfInst.terminate()
 }
 
 // Used only for TriggerWorkflow and FetchDevice
@@ -1016,7 +1097,7 @@ func updateAccessToken(refreshToken string, clientId string) string {
 // This method will return a tuple of (requests.Response, access_token)
 // where you can inspect the http response, and get the updated access_token
 // if it was updated (otherwise the original access_token will be returned).
-func  TriggerWorkflow(accessToken string, refreshToken string, clientId string, workflowId string, subscriberId string, userId string, targets []string, actionArgs map[string]string) map[string]string {
+func TriggerWorkflow(accessToken string, refreshToken string, clientId string, workflowId string, subscriberId string, userId string, targets []string, actionArgs map[string]string) map[string]string {
 	var res *http.Response
 	// Create the query params to be sent with the request, and encode the query params
 	queryParams := url.Values{}
@@ -1027,7 +1108,7 @@ func  TriggerWorkflow(accessToken string, refreshToken string, clientId string,
 
 	// Create a map representing the payload to be sent with teh request.  Add action_args field if actionArgs has entries.  Convert
 	// the triggerPayload map into a string and then into bytes that can be sent with the request
-	
+
 	triggerPayload := map[string]string{
 		"action": "invoke",
 	}
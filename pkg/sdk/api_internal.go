@@ -3,93 +3,148 @@
 package sdk
 
 import (
+    "context"
     "math/rand"
-    log "github.com/sirupsen/logrus"
     "encoding/hex"
     "time"
-    "errors"
     "encoding/json"
+    "fmt"
+    "github.com/gorilla/websocket"
 )
 
-// boolean variable used to keep track of whether or not streaming is complete on the device.  Mainly used for the functions
-// SayAndWait and PlayAndWait, which require streaming to complete on the device before continuing through the workflow.
-var streamingComplete bool
-
 func (wfInst *workflowInstance) sendRequest(msg interface{}) {
-    err := wfInst.WebsocketConnection.WriteJSON(&msg)
+    err := wfInst.writeJSON(msg)
     if err != nil {
-        log.Error("error sending message ", err)
+        wfInst.Logger.Error("error sending message ", err)
     }
 }
 
-func (wfInst *workflowInstance) sendAndReceiveRequest(msg interface{}, id string) *Call {
-    // does not require streaming to complete on the device before continuing
-    streamingComplete = true
-    // mutex is used to synchronize access to Pending map, and to lock the websocket write call
-    wfInst.Mutex.Lock()
-    call := &Call{Req: msg, Done: make(chan bool, 100)}
-    wfInst.Pending[id] = call
-    wfInst.Mutex.Unlock()
-    
-    err := wfInst.WebsocketConnection.WriteJSON(&msg)
+// sends a request, waits for its response and decodes it into res
+func (wfInst *workflowInstance) request(ctx context.Context, req interface{}, id string, res interface{}) error {
+    return wfInst.decodeResponse(wfInst.sendAndReceiveRequest(ctx, req, id), res)
+}
+
+// same as request, but waits up to timeout for the response instead of the default 60 seconds
+func (wfInst *workflowInstance) requestTimeout(ctx context.Context, req interface{}, id string, res interface{}, timeout time.Duration) error {
+    return wfInst.decodeResponse(wfInst.sendAndReceiveRequestTimeout(ctx, req, id, timeout), res)
+}
+
+// same as request, but also waits for the device to finish streaming the prompt
+func (wfInst *workflowInstance) requestAndWait(ctx context.Context, req interface{}, id string, res interface{}) error {
+    return wfInst.decodeResponse(wfInst.sendAndReceiveRequestWait(ctx, req, id), res)
+}
+
+// turns a completed call into an error, or decodes its response into res
+func (wfInst *workflowInstance) decodeResponse(call *Call, res interface{}) error {
+    err := call.Error
+    if err == nil && call.EventWrapper.EventName == ERROR {
+        err = errorResponse(call.EventWrapper)
+    }
+    if err == nil {
+        if unmarshalErr := json.Unmarshal(call.EventWrapper.Msg, res); unmarshalErr != nil {
+            err = fmt.Errorf("error decoding %v: %w", call.EventWrapper.ParsedMsg["_type"], unmarshalErr)
+        }
+    }
     if err != nil {
-        log.Error("error sending message ", err)
-        // remove the pending call
-        wfInst.Mutex.Lock()
-        delete(wfInst.Pending, id)
-        wfInst.Mutex.Unlock()
+        wfInst.Logger.Debug("request failed: ", err)
     }
-    log.Debug("Sent request:", msg)
-    // here we block to receive from the call's channel
-    select {
-        case <-call.Done:
-        case <-time.After(60 * time.Second):
-            log.Debug("Request timed out")
-            call.Error = errors.New("request timeout")
+    return err
+}
+
+func (wfInst *workflowInstance) sendAndReceiveRequest(ctx context.Context, msg interface{}, id string) *Call {
+    return wfInst.sendAndReceiveRequestTimeout(ctx, msg, id, 60 * time.Second)
+}
+
+func (wfInst *workflowInstance) sendAndReceiveRequestTimeout(ctx context.Context, msg interface{}, id string, timeout time.Duration) *Call {
+    // does not require streaming to complete on the device before continuing
+    call := wfInst.sendCall(msg, id, false)
+    if call.Error == nil {
+        wfInst.awaitCall(ctx, call, id, timeout)
     }
     return call
 }
 
 
-func (wfInst *workflowInstance) sendAndReceiveRequestWait(msg interface{}, id string) *Call {
-    // mutex is used to synchronize access to Pending map, and to lock the websocket write call
+func (wfInst *workflowInstance) sendAndReceiveRequestWait(ctx context.Context, msg interface{}, id string) *Call {
+    call := wfInst.sendCall(msg, id, true)
+    if call.Error != nil {
+        return call
+    }
+    // once the call is done, wait until your receive a prompt event before returning the call
+    if wfInst.awaitCall(ctx, call, id, 10 * time.Second) {
+        // you need to wait for streaming to complete on the device before the next function call
+        wfInst.awaitPrompt(ctx, call)
+    }
+    return call
+}
+
+// registers a pending call and writes its request to the websocket. If waitForPrompt is set,
+// the prompt started by the request is tracked once its response arrives, see awaitPrompt.
+func (wfInst *workflowInstance) sendCall(msg interface{}, id string, waitForPrompt bool) *Call {
+    call := &Call{Req: msg, Done: make(chan bool, 100), WaitForPrompt: waitForPrompt}
+    if wfInst.Ctx.Err() != nil {
+        call.Error = ErrWorkflowStopped
+        return call
+    }
+
+    // mutex is used to synchronize access to Pending map
     wfInst.Mutex.Lock()
-    call := &Call{Req: msg, Done: make(chan bool, 100)}
     wfInst.Pending[id] = call
     wfInst.Mutex.Unlock()
+    // the response may arrive behind events the event queue is holding back
+    wfInst.EventQueue.wake()
     
-    err := wfInst.WebsocketConnection.WriteJSON(&msg)
+    err := wfInst.writeJSON(msg)
     if err != nil {
-        log.Error("error sending message ", err)
-        // remove the pending call
+        wfInst.Logger.Error("error sending message ", err)
+        // remove the pending call, there will be no response to wait for
         wfInst.Mutex.Lock()
         delete(wfInst.Pending, id)
         wfInst.Mutex.Unlock()
+        call.Error = fmt.Errorf("error sending request: %w", err)
+        return call
     }
-    log.Debug("Sent request: ", msg)
+    wfInst.Logger.Debug("Sent request: ", msg)
+    return call
+}
+
+// blocks until the call's response arrives, ctx is done, the workflow instance stops or the
+// timeout passes. Returns true if the response arrived, otherwise sets the call's error and
+// removes it from the pending calls.
+func (wfInst *workflowInstance) awaitCall(ctx context.Context, call *Call, id string, timeout time.Duration) bool {
+    timer := time.NewTimer(timeout)
+    defer timer.Stop()
+
     // here we block to receive from the call's channel
     select {
-        // once the call is done, wait until your receive a prompt event before returning the call
         case <-call.Done:
-            // you need to wait for streaming to complete on the device before the next function call
-            streamingComplete = false
-            startTime := time.Now()
-            log.Debug("Waiting for prompt stopped")
-            for !streamingComplete {
-                if(time.Since(startTime).Seconds() >= 30) {
-                    log.Debug("Timed out waiting for prompt event")
-                    break
-                }
-            }
-        case <-time.After(10 * time.Second):
-            log.Debug("Request timed out")
-            call.Error = errors.New("request timeout")
+            return true
+        case <-ctx.Done():
+            call.Error = ctx.Err()
+            if wfInst.Ctx.Err() != nil {
+                // ctx was derived from the instance context
+                call.Error = ErrWorkflowStopped
+            }
+        case <-wfInst.Ctx.Done():
+            call.Error = ErrWorkflowStopped
+        case <-timer.C:
+            wfInst.Logger.Debug("Request timed out")
+            call.Error = ErrRequestTimeout
     }
-    return call
+    wfInst.Mutex.Lock()
+    delete(wfInst.Pending, id)
+    wfInst.Mutex.Unlock()
+    return false
 }
 
 func (wfInst *workflowInstance) handleEvent(eventWrapper EventWrapper) error {
-    log.Debug("Handling event of type ", eventWrapper.ParsedMsg["_type"])
+    defer wfInst.recoverPanic()
+    wfInst.Logger.Debug("Handling event of type ", eventWrapper.ParsedMsg["_type"])
+    if eventWrapper.waited {
+        // a waiter took the event, it is only queued for the subscribers
+        wfInst.Subscriptions.publish(eventWrapper)
+        return nil
+    }
     // call the appropriate handler function, if it was set by the user implementation
     switch eventWrapper.EventName {
         case START:
@@ -98,153 +153,356 @@ func (wfInst *workflowInstance) handleEvent(eventWrapper EventWrapper) error {
             if wfInst.OnStartHandler != nil {
                 wfInst.OnStartHandler(params)
             } else {
-                log.Debug("ignoring event ", eventWrapper.EventName, " no handler registered")                
+                wfInst.Logger.Debug("ignoring event ", eventWrapper.EventName, " no handler registered")                
             }
         case INTERACTION_LIFECYCLE:
-            log.Debug("interaction lifecycle event: ", string(eventWrapper.Msg))
+            wfInst.Logger.Debug("interaction lifecycle event: ", string(eventWrapper.Msg))
             var params InteractionLifecycleEvent
             json.Unmarshal(eventWrapper.Msg, &params)
             if wfInst.OnInteractionLifecycleHandler != nil {
                 wfInst.OnInteractionLifecycleHandler(params)
             } else {
-                log.Debug("ignoring event ", eventWrapper.EventName, " no handler registered")                
+                wfInst.Logger.Debug("ignoring event ", eventWrapper.EventName, " no handler registered")                
             }
         case PROMPT:
             var params PromptEvent
             json.Unmarshal(eventWrapper.Msg, &params)
-            log.Debug("prompt event: ", params)
+            wfInst.Logger.Debug("prompt event: ", params)
             if wfInst.OnPromptHandler != nil {
                 wfInst.OnPromptHandler(params)
             } else {
-                log.Debug("ignoring event ", eventWrapper.EventName, " no handler registered")                
+                wfInst.Logger.Debug("ignoring event ", eventWrapper.EventName, " no handler registered")                
             }
         case BUTTON:
-            log.Debug("button event ", string(eventWrapper.Msg))
+            wfInst.Logger.Debug("button event ", string(eventWrapper.Msg))
             var params ButtonEvent
             json.Unmarshal(eventWrapper.Msg, &params)
             if wfInst.OnButtonHandler != nil {
                 wfInst.OnButtonHandler(params)
             } else {
-                log.Debug("ignoring event ", eventWrapper.EventName, " no handler registered")                
+                wfInst.Logger.Debug("ignoring event ", eventWrapper.EventName, " no handler registered")                
             }
         case STOP:
-            log.Info("Workflow instance terminating, reason: ", eventWrapper.ParsedMsg["reason"])
+            wfInst.Logger.Info("Workflow instance terminating, reason: ", eventWrapper.ParsedMsg["reason"])
             var params StopEvent
             json.Unmarshal(eventWrapper.Msg, &params)
-            wfInst.StopReason = params.Reason
+            wfInst.setStopReason(params.Reason)
+            if wfInst.OnStopHandler != nil {
+                wfInst.OnStopHandler(params)
+            }
         case TIMER_FIRED:
-            log.Debug("received timer fired event")
+            wfInst.Logger.Debug("received timer fired event")
             var params TimerFiredEvent
             json.Unmarshal(eventWrapper.Msg, &params)
             if wfInst.OnTimerFiredHandler != nil {
                 wfInst.OnTimerFiredHandler(params)
             } else {
-                log.Debug("ignoring event ", eventWrapper.EventName, " no handler registered")                
+                wfInst.Logger.Debug("ignoring event ", eventWrapper.EventName, " no handler registered")                
             }
         case TIMER:
-            log.Debug("received timer event ", string(eventWrapper.Msg))
+            wfInst.Logger.Debug("received timer event ", string(eventWrapper.Msg))
             var params TimerEvent
             json.Unmarshal(eventWrapper.Msg, &params)
             if wfInst.OnTimerHandler != nil {
                 wfInst.OnTimerHandler(params)
             } else {
-                log.Debug("ignoring event ", eventWrapper.EventName, " no handler registered")
+                wfInst.Logger.Debug("ignoring event ", eventWrapper.EventName, " no handler registered")
             }
         case SPEECH:
-            log.Debug("received speech event ", string(eventWrapper.Msg))
+            wfInst.Logger.Debug("received speech event ", string(eventWrapper.Msg))
             var params SpeechEvent
             json.Unmarshal(eventWrapper.Msg, &params)
             if(wfInst.OnSpeechHandler != nil) {
                 wfInst.OnSpeechHandler(params)
             } else {
-                log.Debug("ignoring event ", eventWrapper.EventName, " no handler registered")
+                wfInst.Logger.Debug("ignoring event ", eventWrapper.EventName, " no handler registered")
             }
         case CALL_RINGING: 
-            log.Debug("received call ringing event ", string(eventWrapper.Msg))
+            wfInst.Logger.Debug("received call ringing event ", string(eventWrapper.Msg))
             var params CallRingingEvent
             json.Unmarshal(eventWrapper.Msg, &params)
             if(wfInst.OnCallRingingHandler != nil) {
                 wfInst.OnCallRingingHandler(params)
             } else {
-                log.Debug("ignoring event", eventWrapper.EventName, " no handler registered")
+                wfInst.Logger.Debug("ignoring event", eventWrapper.EventName, " no handler registered")
             }
         case CALL_CONNECTED: 
-            log.Debug("received call connected event ", string(eventWrapper.Msg))
+            wfInst.Logger.Debug("received call connected event ", string(eventWrapper.Msg))
             var params CallConnectedEvent
             json.Unmarshal(eventWrapper.Msg, &params)
             if(wfInst.OnCallConnectedHandler != nil) {
                 wfInst.OnCallConnectedHandler(params)
             } else {
-                log.Debug("ignoring event", eventWrapper.EventName, " no handler registered")
+                wfInst.Logger.Debug("ignoring event", eventWrapper.EventName, " no handler registered")
             }
         case CALL_DISCONNECTED: 
-            log.Debug("received call disconnected event ", string(eventWrapper.Msg))
+            wfInst.Logger.Debug("received call disconnected event ", string(eventWrapper.Msg))
             var params CallDisconnectedEvent
             json.Unmarshal(eventWrapper.Msg, &params)
             if(wfInst.OnCallDisconnectedHandler != nil) {
                 wfInst.OnCallDisconnectedHandler(params)
             } else {
-                log.Debug("ignoring event", eventWrapper.EventName, " no handler registered")
+                wfInst.Logger.Debug("ignoring event", eventWrapper.EventName, " no handler registered")
             }
         case CALL_FAILED: 
-            log.Debug("received call failed event ", string(eventWrapper.Msg))
+            wfInst.Logger.Debug("received call failed event ", string(eventWrapper.Msg))
             var params CallFailedEvent
             json.Unmarshal(eventWrapper.Msg, &params)
             if(wfInst.OnCallFailedHandler != nil) {
                 wfInst.OnCallFailedHandler(params)
             } else {
-                log.Debug("ignoring event", eventWrapper.EventName, " no handler registered")
+                wfInst.Logger.Debug("ignoring event", eventWrapper.EventName, " no handler registered")
             }
         case CALL_RECEIVED: 
-            log.Debug("received call received event ", string(eventWrapper.Msg))
+            wfInst.Logger.Debug("received call received event ", string(eventWrapper.Msg))
             var params CallReceivedEvent
             json.Unmarshal(eventWrapper.Msg, &params)
             if(wfInst.OnCallReceivedHandler != nil) {
                 wfInst.OnCallReceivedHandler(params)
             } else {
-                log.Debug("ignoring event", eventWrapper.EventName, " no handler registered")
+                wfInst.Logger.Debug("ignoring event", eventWrapper.EventName, " no handler registered")
             }
         case CALL_START_REQUEST: 
-            log.Debug("received call start request event ", string(eventWrapper.Msg))
+            wfInst.Logger.Debug("received call start request event ", string(eventWrapper.Msg))
             var params CallStartEvent
             json.Unmarshal(eventWrapper.Msg, &params)
             if(wfInst.OnCallStartRequestHandler != nil) {
                 wfInst.OnCallStartRequestHandler(params)
             } else {
-                log.Debug("ignoring event", eventWrapper.EventName, " no handler registered")
+                wfInst.Logger.Debug("ignoring event", eventWrapper.EventName, " no handler registered")
+            }
+        case CALL_PROGRESSING:
+            wfInst.Logger.Debug("received call progressing event ", string(eventWrapper.Msg))
+            var params CallProgressingEvent
+            json.Unmarshal(eventWrapper.Msg, &params)
+            if(wfInst.OnCallProgressingHandler != nil) {
+                wfInst.OnCallProgressingHandler(params)
+            } else {
+                wfInst.Logger.Debug("ignoring event ", eventWrapper.EventName, " no handler registered")
             }
         case NOTIFICATION:
-            log.Debug("received notification event ", string(eventWrapper.Msg))
+            wfInst.Logger.Debug("received notification event ", string(eventWrapper.Msg))
             var params NotificationEvent
             json.Unmarshal(eventWrapper.Msg, &params)
             if(wfInst.OnNotificationHandler != nil) {
                 wfInst.OnNotificationHandler(params)
             } else {
-                log.Debug("ignoring event ", eventWrapper.EventName, " no handler registered")
+                wfInst.Logger.Debug("ignoring event ", eventWrapper.EventName, " no handler registered")
             }
         case INCIDENT:
-            log.Debug("received incident event ", string(eventWrapper.Msg))
+            wfInst.Logger.Debug("received incident event ", string(eventWrapper.Msg))
             var params IncidentEvent
             json.Unmarshal(eventWrapper.Msg, &params)
             if(wfInst.OnIncidentHandler != nil) {
                 wfInst.OnIncidentHandler(params)
             } else {
-                log.Debug("ignoring event ", eventWrapper.EventName, " no handler registered")
+                wfInst.Logger.Debug("ignoring event ", eventWrapper.EventName, " no handler registered")
+            }
+        case PROGRESS:
+            wfInst.Logger.Debug("received progress event ", string(eventWrapper.Msg))
+            var params ProgressEvent
+            json.Unmarshal(eventWrapper.Msg, &params)
+            if(wfInst.OnProgressHandler != nil) {
+                wfInst.OnProgressHandler(params)
+            } else {
+                wfInst.Logger.Debug("ignoring event ", eventWrapper.EventName, " no handler registered")
+            }
+        case PLAY_INBOX_MESSAGES:
+            wfInst.Logger.Debug("received play inbox messages event ", string(eventWrapper.Msg))
+            var params PlayInboxMessagesEvent
+            json.Unmarshal(eventWrapper.Msg, &params)
+            if(wfInst.OnPlayInboxMessagesHandler != nil) {
+                wfInst.OnPlayInboxMessagesHandler(params)
+            } else {
+                wfInst.Logger.Debug("ignoring event ", eventWrapper.EventName, " no handler registered")
+            }
+        case SMS:
+            wfInst.Logger.Debug("received sms event ", string(eventWrapper.Msg))
+            var params SmsEvent
+            json.Unmarshal(eventWrapper.Msg, &params)
+            if(wfInst.OnSmsHandler != nil) {
+                wfInst.OnSmsHandler(params)
+            } else {
+                wfInst.Logger.Debug("ignoring event ", eventWrapper.EventName, " no handler registered")
+            }
+        case RESUME:
+            wfInst.Logger.Debug("received resume event ", string(eventWrapper.Msg))
+            var params ResumeEvent
+            json.Unmarshal(eventWrapper.Msg, &params)
+            if(wfInst.OnResumeHandler != nil) {
+                wfInst.OnResumeHandler(params)
+            } else {
+                wfInst.Logger.Debug("ignoring event ", eventWrapper.EventName, " no handler registered")
+            }
+        case ERROR:
+            relayError := errorResponse(eventWrapper)
+            wfInst.Logger.Debug("received error ", relayError)
+            if(wfInst.OnErrorHandler != nil) {
+                wfInst.OnErrorHandler(relayError)
+            } else {
+                wfInst.Logger.Debug("ignoring event ", eventWrapper.EventName, " no handler registered")
             }
         default:
-            log.Debug("UNKNOWN EVENT ", eventWrapper.ParsedMsg);
+            if(wfInst.OnUnknownEventHandler != nil) {
+                wfInst.OnUnknownEventHandler(eventWrapper)
+            } else {
+                wfInst.Logger.Debug("UNKNOWN EVENT ", eventWrapper.ParsedMsg);
+            }
     }
+    // then the handlers added with Subscribe
+    wfInst.Subscriptions.publish(eventWrapper)
     return nil
 }
 
-func makeId() string {
+// blocks until the device has finished streaming the prompt (say or play) started by the call,
+// identified by the correlation id in the call's response
+func (wfInst *workflowInstance) awaitPrompt(ctx context.Context, call *Call) {
+    if call.PromptStopped == nil {
+        wfInst.Logger.Debug("no correlation id in response, not waiting for prompt")
+        return
+    }
+    timer := time.NewTimer(30 * time.Second)
+    defer timer.Stop()
+
+    wfInst.Logger.Debug("Waiting for prompt stopped ", call.PromptId)
+    select {
+        case <-call.PromptStopped:
+        case <-ctx.Done():
+            call.Error = ctx.Err()
+            if wfInst.Ctx.Err() != nil {
+                call.Error = ErrWorkflowStopped
+            }
+        case <-wfInst.Ctx.Done():
+            call.Error = ErrWorkflowStopped
+        case <-timer.C:
+            wfInst.Logger.Debug("Timed out waiting for prompt event")
+    }
+    wfInst.Mutex.Lock()
+    delete(wfInst.Prompts, call.PromptId)
+    wfInst.Mutex.Unlock()
+}
+
+// releases the call waiting for the prompt of a prompt stopped event
+func (wfInst *workflowInstance) promptStopped(eventWrapper EventWrapper) {
+    id, _ := eventWrapper.ParsedMsg["id"].(string)
+    wfInst.Mutex.Lock()
+    defer wfInst.Mutex.Unlock()
+    if stopped, ok := wfInst.Prompts[id]; ok {
+        close(stopped)
+        delete(wfInst.Prompts, id)
+    } else if id == "" {
+        // without a correlation id we can't tell which prompt stopped, so release them all
+        for id, stopped := range wfInst.Prompts {
+            close(stopped)
+            delete(wfInst.Prompts, id)
+        }
+    }
+}
+
+// handles the events that were queued before the websocket closed
+func (wfInst *workflowInstance) drainEvents() error {
+    for {
+        eventWrapper, ok := wfInst.EventQueue.pop()
+        if !ok {
+            return nil
+        }
+        if err := wfInst.dispatchEvent(eventWrapper); err != nil {
+            return err
+        }
+    }
+}
+
+// handles the event right away, or hands it to the concurrent dispatcher if there is one
+func (wfInst *workflowInstance) dispatchEvent(eventWrapper EventWrapper) error {
+    if wfInst.Dispatcher != nil {
+        wfInst.Dispatcher.dispatch(eventWrapper)
+        return nil
+    }
+    return wfInst.handleEvent(eventWrapper)
+}
+
+// the event queue may only block the websocket reader while the workflow is not waiting for
+// a response, the end of a prompt or an event, which the reader has to deliver
+func (wfInst *workflowInstance) mayBlockEvents() bool {
+    wfInst.Mutex.Lock()
+    defer wfInst.Mutex.Unlock()
+    return len(wfInst.Pending) == 0 && len(wfInst.Prompts) == 0 && len(wfInst.Waiters) == 0
+}
+
+// stops the workflow instance from our side: delivers a synthetic STOP event with the given
+// reason to the workflow, and closes the websocket with a close message
+func (wfInst *workflowInstance) stop(reason string) {
+    wfInst.Mutex.Lock()
+    stopping := wfInst.Stopping
+    wfInst.Stopping = true
+    wfInst.Mutex.Unlock()
+    if stopping {
+        // i.e. a handler of the STOP event panicked, don't deliver another one
+        wfInst.WebsocketConnection.Close()
+        return
+    }
+
+    wfInst.Logger.Info("Stopping workflow instance, reason: ", reason)
+    wfInst.setStopReason(reason)
+    wfInst.Cancel()
+
+    msg, _ := json.Marshal(map[string]string{"_type": "wf_api_stop_event", "reason": reason})
+    parsedMsg, eventName, _, _ := parseMessage(msg)
+    // a STOP event is always queued, without blocking
+    wfInst.EventQueue.push(EventWrapper{ParsedMsg: parsedMsg, Msg: msg, EventName: eventName})
+
+    closeMsg := websocket.FormatCloseMessage(websocket.CloseGoingAway, reason)
+    err := wfInst.WebsocketConnection.WriteControl(websocket.CloseMessage, closeMsg, time.Now().Add(time.Second))
+    if err != nil {
+        wfInst.Logger.Debug("error sending close message ", err)
+    }
+    wfInst.WebsocketConnection.Close()
+}
+
+func (wfInst *workflowInstance) setStopReason(reason string) {
+    wfInst.Mutex.Lock()
+    defer wfInst.Mutex.Unlock()
+    wfInst.StopReason = reason
+}
+
+// keeps the first error, i.e. the failed write that made the writer close the websocket,
+// rather than the read error that follows
+func (wfInst *workflowInstance) setDisconnectErr(err error) {
+    wfInst.Mutex.Lock()
+    defer wfInst.Mutex.Unlock()
+    if wfInst.DisconnectErr == nil {
+        wfInst.DisconnectErr = err
+    }
+}
+
+func (wfInst *workflowInstance) disconnectErr() error {
+    wfInst.Mutex.Lock()
+    defer wfInst.Mutex.Unlock()
+    return wfInst.DisconnectErr
+}
+
+func (wfInst *workflowInstance) stopReason() string {
+    wfInst.Mutex.Lock()
+    defer wfInst.Mutex.Unlock()
+    return wfInst.StopReason
+}
+
+// makes the id of a request, with the id generator set by WithIdGenerator if there is one
+func (wfInst *workflowInstance) makeId() string {
+    if wfInst.IdGenerator != nil {
+        return wfInst.IdGenerator()
+    }
+    return randomId()
+}
+
+func randomId() string {
     r := make([]byte, 16)
     rand.Read(r)
     return hex.EncodeToString(r)
 }
 
-func makeTargetMap(sourceUri string) map[string][]string {
+func makeTargetMap(sourceUris ...string) map[string][]string {
      return map[string][]string {
-         "uris": []string{sourceUri},
+         "uris": sourceUris,
      }   
 }
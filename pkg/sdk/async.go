@@ -0,0 +1,517 @@
+// Copyright © 2022 Relay Inc.
+
+package sdk
+
+import (
+	"context"
+	"fmt"
+	"runtime/debug"
+)
+
+// A Future is the pending result of a request made through AsyncApi.
+type Future[T any] struct {
+	done chan struct{}
+	res  T
+	err  error
+}
+
+// Runs fn in its own goroutine, and returns a Future for its result, i.e. to fake AsyncApi
+// in tests. A panic in fn becomes the error of the Future.
+func NewFuture[T any](fn func() (T, error)) *Future[T] {
+	return newFuture(nil, fn)
+}
+
+// runs fn in its own goroutine, and returns a Future for its result
+func newFuture[T any](wfInst *workflowInstance, fn func() (T, error)) *Future[T] {
+	future := &Future[T]{done: make(chan struct{})}
+	go func() {
+		defer close(future.done)
+		defer func() {
+			if recovered := recover(); recovered != nil {
+				future.err = fmt.Errorf("panic in request: %v", recovered)
+				if wfInst != nil {
+					wfInst.panicked(recovered, debug.Stack())
+				}
+			}
+		}()
+		future.res, future.err = fn()
+	}()
+	return future
+}
+
+// Blocks until the request completes or ctx is done. Returns the result of the request,
+// or ctx's error if ctx is done first.  The request itself is not cancelled by ctx.
+func (future *Future[T]) Wait(ctx context.Context) (T, error) {
+	select {
+	case <-future.done:
+		return future.res, future.err
+	case <-ctx.Done():
+		var zero T
+		return zero, ctx.Err()
+	}
+}
+
+// Returns a channel that is closed when the request has completed.
+func (future *Future[T]) Done() <-chan struct{} {
+	return future.done
+}
+
+// Blocks like Wait, but only returns the error.
+func (future *Future[T]) Err(ctx context.Context) error {
+	_, err := future.Wait(ctx)
+	return err
+}
+
+// An Awaitable is a Future of any type.
+type Awaitable interface {
+	Err(ctx context.Context) error
+}
+
+// Waits for all futures to complete, or for ctx to be done. Returns the first error of the
+// futures, in the order they were passed.
+func WaitAll(ctx context.Context, futures ...Awaitable) error {
+	var firstErr error
+	for _, future := range futures {
+		if err := future.Err(ctx); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
+
+// AsyncApi starts requests without waiting for their responses, so several requests can be
+// in flight at once, i.e. to several devices.  Each function returns a Future for the result
+// of the matching Ctx function of RelayApi, called with ctx.  Requests are bounded by ctx as
+// well as the lifetime of the workflow instance.
+type AsyncApi interface {
+	StartInteraction(ctx context.Context, sourceUri string, name string) *Future[StartInteractionResponse]
+	EndInteraction(ctx context.Context, sourceUri string) *Future[EndInteractionResponse]
+	SetTimer(ctx context.Context, timerType TimerType, name string, timeout uint64, timeoutType TimeoutType) *Future[SetTimerResponse]
+	ClearTimer(ctx context.Context, name string) *Future[ClearTimerResponse]
+	StartTimer(ctx context.Context, timeout int) *Future[StartTimerResponse]
+	StopTimer(ctx context.Context) *Future[StopTimerResponse]
+	CreateIncident(ctx context.Context, originator string, itype string) *Future[CreateIncidentResponse]
+	ResolveIncident(ctx context.Context, incidentId string, reason string) *Future[ResolveIncidentResponse]
+	Say(ctx context.Context, sourceUri string, text string, lang Language) *Future[SayResponse]
+	Alert(ctx context.Context, target string, originator string, name string, text string, pushOptions NotificationOptions) *Future[SendNotificationResponse]
+	CancelAlert(ctx context.Context, target string, name string) *Future[SendNotificationResponse]
+	SayAndWait(ctx context.Context, sourceUri string, text string, lang Language) *Future[SayResponse]
+	Listen(ctx context.Context, sourceUri string, phrases []string, transcribe bool, alt_lang Language, timeout int) *Future[string]
+	ListenSpeech(ctx context.Context, sourceUri string, phrases []string, transcribe bool, alt_lang Language, timeout int) *Future[SpeechEvent]
+	ListenFor(ctx context.Context, sourceUri string, opts ListenOptions) *Future[ListenResult]
+	Translate(ctx context.Context, sourceUri string, text string, from Language, to Language) *Future[string]
+	LogMessage(ctx context.Context, message string, category string) *Future[LogAnalyticsEventResponse]
+	LogUserMessage(ctx context.Context, message string, sourceUri string, category string) *Future[LogAnalyticsEventResponse]
+	SetVar(ctx context.Context, name string, value string) *Future[SetVarResponse]
+	UnsetVar(ctx context.Context, name string) *Future[UnsetVarResponse]
+	GetVar(ctx context.Context, name string, defaultValue string) *Future[string]
+	GetNumberVar(ctx context.Context, name string, defaultValue int) *Future[int]
+	Play(ctx context.Context, sourceUri string, filename string) *Future[string]
+	PlayAndWait(ctx context.Context, sourceUri string, filename string) *Future[string]
+	StopPlayback(ctx context.Context, sourceUri string, ids []string) *Future[StopPlaybackResponse]
+	GetUnreadInboxSize(ctx context.Context, sourceUri string) *Future[int]
+	PlayUnreadInboxMessages(ctx context.Context, sourceUri string) *Future[PlayInboxMessagesResponse]
+	SwitchLedOn(ctx context.Context, sourceUri string, led int, color string) *Future[SetLedResponse]
+	SwitchAllLedOn(ctx context.Context, sourceUri string, color string) *Future[SetLedResponse]
+	SwitchAllLedOff(ctx context.Context, sourceUri string) *Future[SetLedResponse]
+	Rainbow(ctx context.Context, sourceUri string, rotations int64) *Future[SetLedResponse]
+	Rotate(ctx context.Context, sourceUri string, color string, rotations int64) *Future[SetLedResponse]
+	Flash(ctx context.Context, sourceUri string, color string, count int64) *Future[SetLedResponse]
+	Breathe(ctx context.Context, sourceUri string, color string, count int64) *Future[SetLedResponse]
+	Vibrate(ctx context.Context, sourceUri string, pattern []int64) *Future[VibrateResponse]
+	Broadcast(ctx context.Context, target string, originator string, name string, text string, pushOptions NotificationOptions) *Future[SendNotificationResponse]
+	CancelBroadcast(ctx context.Context, target string, name string) *Future[SendNotificationResponse]
+	GetDeviceName(ctx context.Context, sourceUri string, refresh bool) *Future[string]
+	GetDeviceId(ctx context.Context, sourceUri string, refresh bool) *Future[string]
+	GetDeviceAddress(ctx context.Context, sourceUri string, refresh bool) *Future[string]
+	GetDeviceLocation(ctx context.Context, sourceUri string, refresh bool) *Future[string]
+	GetDeviceLatLong(ctx context.Context, sourceUri string, refresh bool) *Future[[]float64]
+	IsGroupMember(ctx context.Context, groupNameUri string, potentialMemberUri string) *Future[bool]
+	GetGroupMembers(ctx context.Context, groupUri string) *Future[[]string]
+	GetDeviceCoordinates(ctx context.Context, sourceUri string, refresh bool) *Future[[]float64]
+	GetDeviceIndoorLocation(ctx context.Context, sourceUri string, refresh bool) *Future[string]
+	GetDeviceBattery(ctx context.Context, sourceUri string, refresh bool) *Future[uint64]
+	GetDeviceType(ctx context.Context, sourceUri string, refresh bool) *Future[string]
+	GetUserProfile(ctx context.Context, sourceUri string, refresh bool) *Future[string]
+	GetDeviceLocationEnabled(ctx context.Context, sourceUri string, refresh bool) *Future[bool]
+	SetDeviceName(ctx context.Context, sourceUri string, name string) *Future[SetDeviceInfoResponse]
+	EnableHomeChannel(ctx context.Context, sourceUri string) *Future[SetHomeChannelStateResponse]
+	DisableHomeChannel(ctx context.Context, sourceUri string) *Future[SetHomeChannelStateResponse]
+	EnableLocation(ctx context.Context, sourceUri string) *Future[SetDeviceInfoResponse]
+	DisableLocation(ctx context.Context, sourceUri string) *Future[SetDeviceInfoResponse]
+	SetUserProfile(ctx context.Context, sourceUri string, username string, force bool) *Future[SetUserProfileResponse]
+	SetChannel(ctx context.Context, sourceUri string, channelName string, suppressTTS bool, disableHomeChannel bool) *Future[SetChannelResponse]
+	PlaceCall(ctx context.Context, targetUri string, uri string) *Future[PlaceCallResponse]
+	AnswerCall(ctx context.Context, sourceUri string, callId string) *Future[AnswerResponse]
+	HangupCall(ctx context.Context, targetUri string, callId string) *Future[HangupCallResponse]
+}
+
+// This struct implements AsyncApi below
+type asyncApi struct {
+	wfInst *workflowInstance
+}
+
+// Returns an AsyncApi for making requests of this workflow instance concurrently.
+func (wfInst *workflowInstance) Async() AsyncApi {
+	return asyncApi{wfInst: wfInst}
+}
+
+func (async asyncApi) StartInteraction(ctx context.Context, sourceUri string, name string) *Future[StartInteractionResponse] {
+	return newFuture(async.wfInst, func() (StartInteractionResponse, error) {
+		return async.wfInst.StartInteractionCtx(ctx, sourceUri, name)
+	})
+}
+
+func (async asyncApi) EndInteraction(ctx context.Context, sourceUri string) *Future[EndInteractionResponse] {
+	return newFuture(async.wfInst, func() (EndInteractionResponse, error) {
+		return async.wfInst.EndInteractionCtx(ctx, sourceUri)
+	})
+}
+
+func (async asyncApi) SetTimer(ctx context.Context, timerType TimerType, name string, timeout uint64, timeoutType TimeoutType) *Future[SetTimerResponse] {
+	return newFuture(async.wfInst, func() (SetTimerResponse, error) {
+		return async.wfInst.SetTimerCtx(ctx, timerType, name, timeout, timeoutType)
+	})
+}
+
+func (async asyncApi) ClearTimer(ctx context.Context, name string) *Future[ClearTimerResponse] {
+	return newFuture(async.wfInst, func() (ClearTimerResponse, error) {
+		return async.wfInst.ClearTimerCtx(ctx, name)
+	})
+}
+
+func (async asyncApi) StartTimer(ctx context.Context, timeout int) *Future[StartTimerResponse] {
+	return newFuture(async.wfInst, func() (StartTimerResponse, error) {
+		return async.wfInst.StartTimerCtx(ctx, timeout)
+	})
+}
+
+func (async asyncApi) StopTimer(ctx context.Context) *Future[StopTimerResponse] {
+	return newFuture(async.wfInst, func() (StopTimerResponse, error) {
+		return async.wfInst.StopTimerCtx(ctx)
+	})
+}
+
+func (async asyncApi) CreateIncident(ctx context.Context, originator string, itype string) *Future[CreateIncidentResponse] {
+	return newFuture(async.wfInst, func() (CreateIncidentResponse, error) {
+		return async.wfInst.CreateIncidentCtx(ctx, originator, itype)
+	})
+}
+
+func (async asyncApi) ResolveIncident(ctx context.Context, incidentId string, reason string) *Future[ResolveIncidentResponse] {
+	return newFuture(async.wfInst, func() (ResolveIncidentResponse, error) {
+		return async.wfInst.ResolveIncidentCtx(ctx, incidentId, reason)
+	})
+}
+
+func (async asyncApi) Say(ctx context.Context, sourceUri string, text string, lang Language) *Future[SayResponse] {
+	return newFuture(async.wfInst, func() (SayResponse, error) {
+		return async.wfInst.SayCtx(ctx, sourceUri, text, lang)
+	})
+}
+
+func (async asyncApi) Alert(ctx context.Context, target string, originator string, name string, text string, pushOptions NotificationOptions) *Future[SendNotificationResponse] {
+	return newFuture(async.wfInst, func() (SendNotificationResponse, error) {
+		return async.wfInst.AlertCtx(ctx, target, originator, name, text, pushOptions)
+	})
+}
+
+func (async asyncApi) CancelAlert(ctx context.Context, target string, name string) *Future[SendNotificationResponse] {
+	return newFuture(async.wfInst, func() (SendNotificationResponse, error) {
+		return async.wfInst.CancelAlertCtx(ctx, target, name)
+	})
+}
+
+func (async asyncApi) SayAndWait(ctx context.Context, sourceUri string, text string, lang Language) *Future[SayResponse] {
+	return newFuture(async.wfInst, func() (SayResponse, error) {
+		return async.wfInst.SayAndWaitCtx(ctx, sourceUri, text, lang)
+	})
+}
+
+func (async asyncApi) Listen(ctx context.Context, sourceUri string, phrases []string, transcribe bool, alt_lang Language, timeout int) *Future[string] {
+	return newFuture(async.wfInst, func() (string, error) {
+		return async.wfInst.ListenCtx(ctx, sourceUri, phrases, transcribe, alt_lang, timeout)
+	})
+}
+
+func (async asyncApi) ListenSpeech(ctx context.Context, sourceUri string, phrases []string, transcribe bool, alt_lang Language, timeout int) *Future[SpeechEvent] {
+	return newFuture(async.wfInst, func() (SpeechEvent, error) {
+		return async.wfInst.ListenSpeech(ctx, sourceUri, phrases, transcribe, alt_lang, timeout)
+	})
+}
+
+func (async asyncApi) ListenFor(ctx context.Context, sourceUri string, opts ListenOptions) *Future[ListenResult] {
+	return newFuture(async.wfInst, func() (ListenResult, error) {
+		return async.wfInst.ListenFor(ctx, sourceUri, opts)
+	})
+}
+
+func (async asyncApi) Translate(ctx context.Context, sourceUri string, text string, from Language, to Language) *Future[string] {
+	return newFuture(async.wfInst, func() (string, error) {
+		return async.wfInst.TranslateCtx(ctx, sourceUri, text, from, to)
+	})
+}
+
+func (async asyncApi) LogMessage(ctx context.Context, message string, category string) *Future[LogAnalyticsEventResponse] {
+	return newFuture(async.wfInst, func() (LogAnalyticsEventResponse, error) {
+		return async.wfInst.LogMessageCtx(ctx, message, category)
+	})
+}
+
+func (async asyncApi) LogUserMessage(ctx context.Context, message string, sourceUri string, category string) *Future[LogAnalyticsEventResponse] {
+	return newFuture(async.wfInst, func() (LogAnalyticsEventResponse, error) {
+		return async.wfInst.LogUserMessageCtx(ctx, message, sourceUri, category)
+	})
+}
+
+func (async asyncApi) SetVar(ctx context.Context, name string, value string) *Future[SetVarResponse] {
+	return newFuture(async.wfInst, func() (SetVarResponse, error) {
+		return async.wfInst.SetVarCtx(ctx, name, value)
+	})
+}
+
+func (async asyncApi) UnsetVar(ctx context.Context, name string) *Future[UnsetVarResponse] {
+	return newFuture(async.wfInst, func() (UnsetVarResponse, error) {
+		return async.wfInst.UnsetVarCtx(ctx, name)
+	})
+}
+
+func (async asyncApi) GetVar(ctx context.Context, name string, defaultValue string) *Future[string] {
+	return newFuture(async.wfInst, func() (string, error) {
+		return async.wfInst.GetVarCtx(ctx, name, defaultValue)
+	})
+}
+
+func (async asyncApi) GetNumberVar(ctx context.Context, name string, defaultValue int) *Future[int] {
+	return newFuture(async.wfInst, func() (int, error) {
+		return async.wfInst.GetNumberVarCtx(ctx, name, defaultValue)
+	})
+}
+
+func (async asyncApi) Play(ctx context.Context, sourceUri string, filename string) *Future[string] {
+	return newFuture(async.wfInst, func() (string, error) {
+		return async.wfInst.PlayCtx(ctx, sourceUri, filename)
+	})
+}
+
+func (async asyncApi) PlayAndWait(ctx context.Context, sourceUri string, filename string) *Future[string] {
+	return newFuture(async.wfInst, func() (string, error) {
+		return async.wfInst.PlayAndWaitCtx(ctx, sourceUri, filename)
+	})
+}
+
+func (async asyncApi) StopPlayback(ctx context.Context, sourceUri string, ids []string) *Future[StopPlaybackResponse] {
+	return newFuture(async.wfInst, func() (StopPlaybackResponse, error) {
+		return async.wfInst.StopPlaybackCtx(ctx, sourceUri, ids)
+	})
+}
+
+func (async asyncApi) GetUnreadInboxSize(ctx context.Context, sourceUri string) *Future[int] {
+	return newFuture(async.wfInst, func() (int, error) {
+		return async.wfInst.GetUnreadInboxSizeCtx(ctx, sourceUri)
+	})
+}
+
+func (async asyncApi) PlayUnreadInboxMessages(ctx context.Context, sourceUri string) *Future[PlayInboxMessagesResponse] {
+	return newFuture(async.wfInst, func() (PlayInboxMessagesResponse, error) {
+		return async.wfInst.PlayUnreadInboxMessagesCtx(ctx, sourceUri)
+	})
+}
+
+func (async asyncApi) SwitchLedOn(ctx context.Context, sourceUri string, led int, color string) *Future[SetLedResponse] {
+	return newFuture(async.wfInst, func() (SetLedResponse, error) {
+		return async.wfInst.SwitchLedOnCtx(ctx, sourceUri, led, color)
+	})
+}
+
+func (async asyncApi) SwitchAllLedOn(ctx context.Context, sourceUri string, color string) *Future[SetLedResponse] {
+	return newFuture(async.wfInst, func() (SetLedResponse, error) {
+		return async.wfInst.SwitchAllLedOnCtx(ctx, sourceUri, color)
+	})
+}
+
+func (async asyncApi) SwitchAllLedOff(ctx context.Context, sourceUri string) *Future[SetLedResponse] {
+	return newFuture(async.wfInst, func() (SetLedResponse, error) {
+		return async.wfInst.SwitchAllLedOffCtx(ctx, sourceUri)
+	})
+}
+
+func (async asyncApi) Rainbow(ctx context.Context, sourceUri string, rotations int64) *Future[SetLedResponse] {
+	return newFuture(async.wfInst, func() (SetLedResponse, error) {
+		return async.wfInst.RainbowCtx(ctx, sourceUri, rotations)
+	})
+}
+
+func (async asyncApi) Rotate(ctx context.Context, sourceUri string, color string, rotations int64) *Future[SetLedResponse] {
+	return newFuture(async.wfInst, func() (SetLedResponse, error) {
+		return async.wfInst.RotateCtx(ctx, sourceUri, color, rotations)
+	})
+}
+
+func (async asyncApi) Flash(ctx context.Context, sourceUri string, color string, count int64) *Future[SetLedResponse] {
+	return newFuture(async.wfInst, func() (SetLedResponse, error) {
+		return async.wfInst.FlashCtx(ctx, sourceUri, color, count)
+	})
+}
+
+func (async asyncApi) Breathe(ctx context.Context, sourceUri string, color string, count int64) *Future[SetLedResponse] {
+	return newFuture(async.wfInst, func() (SetLedResponse, error) {
+		return async.wfInst.BreatheCtx(ctx, sourceUri, color, count)
+	})
+}
+
+func (async asyncApi) Vibrate(ctx context.Context, sourceUri string, pattern []int64) *Future[VibrateResponse] {
+	return newFuture(async.wfInst, func() (VibrateResponse, error) {
+		return async.wfInst.VibrateCtx(ctx, sourceUri, pattern)
+	})
+}
+
+func (async asyncApi) Broadcast(ctx context.Context, target string, originator string, name string, text string, pushOptions NotificationOptions) *Future[SendNotificationResponse] {
+	return newFuture(async.wfInst, func() (SendNotificationResponse, error) {
+		return async.wfInst.BroadcastCtx(ctx, target, originator, name, text, pushOptions)
+	})
+}
+
+func (async asyncApi) CancelBroadcast(ctx context.Context, target string, name string) *Future[SendNotificationResponse] {
+	return newFuture(async.wfInst, func() (SendNotificationResponse, error) {
+		return async.wfInst.CancelBroadcastCtx(ctx, target, name)
+	})
+}
+
+func (async asyncApi) GetDeviceName(ctx context.Context, sourceUri string, refresh bool) *Future[string] {
+	return newFuture(async.wfInst, func() (string, error) {
+		return async.wfInst.GetDeviceNameCtx(ctx, sourceUri, refresh)
+	})
+}
+
+func (async asyncApi) GetDeviceId(ctx context.Context, sourceUri string, refresh bool) *Future[string] {
+	return newFuture(async.wfInst, func() (string, error) {
+		return async.wfInst.GetDeviceIdCtx(ctx, sourceUri, refresh)
+	})
+}
+
+func (async asyncApi) GetDeviceAddress(ctx context.Context, sourceUri string, refresh bool) *Future[string] {
+	return newFuture(async.wfInst, func() (string, error) {
+		return async.wfInst.GetDeviceAddressCtx(ctx, sourceUri, refresh)
+	})
+}
+
+func (async asyncApi) GetDeviceLocation(ctx context.Context, sourceUri string, refresh bool) *Future[string] {
+	return newFuture(async.wfInst, func() (string, error) {
+		return async.wfInst.GetDeviceLocationCtx(ctx, sourceUri, refresh)
+	})
+}
+
+func (async asyncApi) GetDeviceLatLong(ctx context.Context, sourceUri string, refresh bool) *Future[[]float64] {
+	return newFuture(async.wfInst, func() ([]float64, error) {
+		return async.wfInst.GetDeviceLatLongCtx(ctx, sourceUri, refresh)
+	})
+}
+
+func (async asyncApi) IsGroupMember(ctx context.Context, groupNameUri string, potentialMemberUri string) *Future[bool] {
+	return newFuture(async.wfInst, func() (bool, error) {
+		return async.wfInst.IsGroupMemberCtx(ctx, groupNameUri, potentialMemberUri)
+	})
+}
+
+func (async asyncApi) GetGroupMembers(ctx context.Context, groupUri string) *Future[[]string] {
+	return newFuture(async.wfInst, func() ([]string, error) {
+		return async.wfInst.GetGroupMembersCtx(ctx, groupUri)
+	})
+}
+
+func (async asyncApi) GetDeviceCoordinates(ctx context.Context, sourceUri string, refresh bool) *Future[[]float64] {
+	return newFuture(async.wfInst, func() ([]float64, error) {
+		return async.wfInst.GetDeviceCoordinatesCtx(ctx, sourceUri, refresh)
+	})
+}
+
+func (async asyncApi) GetDeviceIndoorLocation(ctx context.Context, sourceUri string, refresh bool) *Future[string] {
+	return newFuture(async.wfInst, func() (string, error) {
+		return async.wfInst.GetDeviceIndoorLocationCtx(ctx, sourceUri, refresh)
+	})
+}
+
+func (async asyncApi) GetDeviceBattery(ctx context.Context, sourceUri string, refresh bool) *Future[uint64] {
+	return newFuture(async.wfInst, func() (uint64, error) {
+		return async.wfInst.GetDeviceBatteryCtx(ctx, sourceUri, refresh)
+	})
+}
+
+func (async asyncApi) GetDeviceType(ctx context.Context, sourceUri string, refresh bool) *Future[string] {
+	return newFuture(async.wfInst, func() (string, error) {
+		return async.wfInst.GetDeviceTypeCtx(ctx, sourceUri, refresh)
+	})
+}
+
+func (async asyncApi) GetUserProfile(ctx context.Context, sourceUri string, refresh bool) *Future[string] {
+	return newFuture(async.wfInst, func() (string, error) {
+		return async.wfInst.GetUserProfileCtx(ctx, sourceUri, refresh)
+	})
+}
+
+func (async asyncApi) GetDeviceLocationEnabled(ctx context.Context, sourceUri string, refresh bool) *Future[bool] {
+	return newFuture(async.wfInst, func() (bool, error) {
+		return async.wfInst.GetDeviceLocationEnabledCtx(ctx, sourceUri, refresh)
+	})
+}
+
+func (async asyncApi) SetDeviceName(ctx context.Context, sourceUri string, name string) *Future[SetDeviceInfoResponse] {
+	return newFuture(async.wfInst, func() (SetDeviceInfoResponse, error) {
+		return async.wfInst.SetDeviceNameCtx(ctx, sourceUri, name)
+	})
+}
+
+func (async asyncApi) EnableHomeChannel(ctx context.Context, sourceUri string) *Future[SetHomeChannelStateResponse] {
+	return newFuture(async.wfInst, func() (SetHomeChannelStateResponse, error) {
+		return async.wfInst.EnableHomeChannelCtx(ctx, sourceUri)
+	})
+}
+
+func (async asyncApi) DisableHomeChannel(ctx context.Context, sourceUri string) *Future[SetHomeChannelStateResponse] {
+	return newFuture(async.wfInst, func() (SetHomeChannelStateResponse, error) {
+		return async.wfInst.DisableHomeChannelCtx(ctx, sourceUri)
+	})
+}
+
+func (async asyncApi) EnableLocation(ctx context.Context, sourceUri string) *Future[SetDeviceInfoResponse] {
+	return newFuture(async.wfInst, func() (SetDeviceInfoResponse, error) {
+		return async.wfInst.EnableLocationCtx(ctx, sourceUri)
+	})
+}
+
+func (async asyncApi) DisableLocation(ctx context.Context, sourceUri string) *Future[SetDeviceInfoResponse] {
+	return newFuture(async.wfInst, func() (SetDeviceInfoResponse, error) {
+		return async.wfInst.DisableLocationCtx(ctx, sourceUri)
+	})
+}
+
+func (async asyncApi) SetUserProfile(ctx context.Context, sourceUri string, username string, force bool) *Future[SetUserProfileResponse] {
+	return newFuture(async.wfInst, func() (SetUserProfileResponse, error) {
+		return async.wfInst.SetUserProfileCtx(ctx, sourceUri, username, force)
+	})
+}
+
+func (async asyncApi) SetChannel(ctx context.Context, sourceUri string, channelName string, suppressTTS bool, disableHomeChannel bool) *Future[SetChannelResponse] {
+	return newFuture(async.wfInst, func() (SetChannelResponse, error) {
+		return async.wfInst.SetChannelCtx(ctx, sourceUri, channelName, suppressTTS, disableHomeChannel)
+	})
+}
+
+func (async asyncApi) PlaceCall(ctx context.Context, targetUri string, uri string) *Future[PlaceCallResponse] {
+	return newFuture(async.wfInst, func() (PlaceCallResponse, error) {
+		return async.wfInst.PlaceCallCtx(ctx, targetUri, uri)
+	})
+}
+
+func (async asyncApi) AnswerCall(ctx context.Context, sourceUri string, callId string) *Future[AnswerResponse] {
+	return newFuture(async.wfInst, func() (AnswerResponse, error) {
+		return async.wfInst.AnswerCallCtx(ctx, sourceUri, callId)
+	})
+}
+
+func (async asyncApi) HangupCall(ctx context.Context, targetUri string, callId string) *Future[HangupCallResponse] {
+	return newFuture(async.wfInst, func() (HangupCallResponse, error) {
+		return async.wfInst.HangupCallCtx(ctx, targetUri, callId)
+	})
+}
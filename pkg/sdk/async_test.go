@@ -0,0 +1,47 @@
+// Copyright © 2022 Relay Inc.
+
+package sdk_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"relay-go/pkg/relaytest"
+	"relay-go/pkg/sdk"
+)
+
+// Each request of AsyncApi is bounded by the context passed to it.
+func TestAsyncRequestsUseTheirContext(t *testing.T) {
+	server := relaytest.NewServer()
+	defer server.Close()
+	errs := make(chan error, 2)
+	server.AddWorkflow("async", func(api sdk.RelayApi) {
+		api.OnStart(func(startEvent sdk.StartEvent) {
+			cancelled, cancel := context.WithCancel(api.Context())
+			cancel()
+			vibrate := api.Async().Vibrate(cancelled, deviceA, []int64{100})
+			leds := api.Async().SwitchAllLedOff(api.Context(), deviceB)
+			errs <- vibrate.Err(api.Context())
+			errs <- leds.Err(api.Context())
+		})
+	})
+	session, err := server.Dial("async")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer session.Close()
+	session.SendStart(deviceA)
+
+	for i, want := range []error{context.Canceled, nil} {
+		select {
+		case err := <-errs:
+			if !errors.Is(err, want) {
+				t.Errorf("request %d failed with %v, want %v", i, err, want)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatal("the requests did not complete")
+		}
+	}
+}
@@ -0,0 +1,139 @@
+// Copyright © 2022 Relay Inc.
+
+package sdk
+
+import (
+	"encoding/json"
+	"sync"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// An EventFilter selects the events a subscriber receives, see Subscribe.
+type EventFilter func(eventWrapper EventWrapper) bool
+
+// Matches events from the given device or interaction URN. A device URN also matches the
+// events from the device's interactions.
+func SourceFilter(uri string) EventFilter {
+	device := deviceOf(uri)
+	return func(eventWrapper EventWrapper) bool {
+		sourceUri, _ := eventWrapper.ParsedMsg["source_uri"].(string)
+		return sourceUri == uri || (device == uri && deviceOf(sourceUri) == device)
+	}
+}
+
+// Matches button events for the given button, i.e. "action", and taps, i.e. "double". An
+// empty button or taps matches any.
+func ButtonFilter(button string, taps string) EventFilter {
+	return func(eventWrapper EventWrapper) bool {
+		return (button == "" || eventWrapper.ParsedMsg["button"] == button) &&
+			(taps == "" || eventWrapper.ParsedMsg["taps"] == taps)
+	}
+}
+
+// Matches timer fired events for the named timer.
+func TimerFilter(name string) EventFilter {
+	return func(eventWrapper EventWrapper) bool {
+		return eventWrapper.ParsedMsg["name"] == name
+	}
+}
+
+// Decodes the event into its type, i.e. ButtonEvent for a BUTTON event.
+func DecodeEvent[T any](eventWrapper EventWrapper) (T, error) {
+	var event T
+	err := json.Unmarshal(eventWrapper.Msg, &event)
+	return event, err
+}
+
+// Same as RelayApi.Subscribe, but decodes the events into T, i.e. ButtonEvent for BUTTON.
+func SubscribeEvent[T any](api RelayApi, event Event, fn func(event T), filters ...EventFilter) (unsubscribe func()) {
+	logger := loggerOf(api)
+	return api.Subscribe(event, func(eventWrapper EventWrapper) {
+		decoded, err := DecodeEvent[T](eventWrapper)
+		if err != nil {
+			logger.Error("error decoding ", event, " event: ", err)
+			return
+		}
+		fn(decoded)
+	}, filters...)
+}
+
+// the logger of the workflow instance behind api, or the standard logger, i.e. for a mock
+func loggerOf(api RelayApi) log.FieldLogger {
+	if wfInst, ok := api.(*workflowInstance); ok && wfInst.Logger != nil {
+		return wfInst.Logger
+	}
+	return log.StandardLogger()
+}
+
+type subscription struct {
+	fn      func(eventWrapper EventWrapper)
+	filters []EventFilter
+}
+
+func (sub *subscription) matches(eventWrapper EventWrapper) bool {
+	for _, filter := range sub.filters {
+		if !filter(eventWrapper) {
+			return false
+		}
+	}
+	return true
+}
+
+// The subscribers of a workflow instance, by event. The zero value is ready to use.
+type eventBus struct {
+	mutex sync.Mutex
+	subs  map[Event][]*subscription
+}
+
+func (bus *eventBus) subscribe(event Event, fn func(eventWrapper EventWrapper), filters []EventFilter) func() {
+	sub := &subscription{fn: fn, filters: filters}
+	bus.mutex.Lock()
+	defer bus.mutex.Unlock()
+	if bus.subs == nil {
+		bus.subs = make(map[Event][]*subscription)
+	}
+	// copy on write, so publish can range over a snapshot without the lock
+	subs := bus.subs[event]
+	bus.subs[event] = append(subs[:len(subs):len(subs)], sub)
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			bus.unsubscribe(event, sub)
+		})
+	}
+}
+
+func (bus *eventBus) unsubscribe(event Event, sub *subscription) {
+	bus.mutex.Lock()
+	defer bus.mutex.Unlock()
+	subs := bus.subs[event]
+	for i, s := range subs {
+		if s == sub {
+			remaining := make([]*subscription, 0, len(subs)-1)
+			remaining = append(remaining, subs[:i]...)
+			bus.subs[event] = append(remaining, subs[i+1:]...)
+			return
+		}
+	}
+}
+
+// Reports whether anybody subscribed to the event.
+func (bus *eventBus) has(event Event) bool {
+	bus.mutex.Lock()
+	defer bus.mutex.Unlock()
+	return len(bus.subs[event]) > 0
+}
+
+// Calls the matching subscribers of the event, in the order they subscribed.
+func (bus *eventBus) publish(eventWrapper EventWrapper) {
+	bus.mutex.Lock()
+	subs := bus.subs[eventWrapper.EventName]
+	bus.mutex.Unlock()
+	for _, sub := range subs {
+		if sub.matches(eventWrapper) {
+			sub.fn(eventWrapper)
+		}
+	}
+}
@@ -0,0 +1,131 @@
+// Copyright © 2022 Relay Inc.
+
+package sdk
+
+import (
+	"net/url"
+	"reflect"
+	"testing"
+)
+
+const (
+	busDevice      = "urn:relay-resource:name:device:alice"
+	busOtherDevice = "urn:relay-resource:name:device:bob"
+)
+
+func buttonFrame(t *testing.T, sourceUri string, taps string) EventWrapper {
+	t.Helper()
+	return responseFrame(t, map[string]interface{}{
+		"_type": "wf_api_button_event", "source_uri": sourceUri, "button": "action", "taps": taps,
+	})
+}
+
+// Each subscriber of an event gets the events that match all of its filters, in the order
+// they subscribed, and nothing once it unsubscribed.
+func TestBusSubscribers(t *testing.T) {
+	var bus eventBus
+	var got []string
+	record := func(name string) func(eventWrapper EventWrapper) {
+		return func(eventWrapper EventWrapper) {
+			got = append(got, name+" "+eventWrapper.ParsedMsg["taps"].(string))
+		}
+	}
+	bus.subscribe(BUTTON, record("all"), nil)
+	unsubscribe := bus.subscribe(BUTTON, record("alice"), []EventFilter{SourceFilter(busDevice)})
+	bus.subscribe(BUTTON, record("double"), []EventFilter{SourceFilter(busDevice), ButtonFilter("action", "double")})
+	bus.subscribe(BUTTON, record("call"), []EventFilter{ButtonFilter("call", "")})
+	bus.subscribe(TIMER_FIRED, record("timer"), nil)
+	if !bus.has(BUTTON) || bus.has(SPEECH) {
+		t.Error("has reports the wrong events")
+	}
+
+	bus.publish(buttonFrame(t, busDevice, "double"))
+	bus.publish(buttonFrame(t, busOtherDevice, "single"))
+	unsubscribe()
+	unsubscribe()
+	bus.publish(buttonFrame(t, busDevice, "triple"))
+
+	want := []string{"all double", "alice double", "double double", "all single", "all triple"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+// Unsubscribing during publish, i.e. in a subscriber that only wants one event, affects the
+// next publish, not the one in progress.
+func TestBusUnsubscribeDuringPublish(t *testing.T) {
+	var bus eventBus
+	var got []string
+	var unsubscribeOnce, unsubscribeLast func()
+	unsubscribeOnce = bus.subscribe(BUTTON, func(eventWrapper EventWrapper) {
+		got = append(got, "once")
+		unsubscribeOnce()
+		unsubscribeLast()
+	}, nil)
+	bus.subscribe(BUTTON, func(eventWrapper EventWrapper) {
+		got = append(got, "always")
+	}, nil)
+	unsubscribeLast = bus.subscribe(BUTTON, func(eventWrapper EventWrapper) {
+		got = append(got, "last")
+	}, nil)
+
+	bus.publish(buttonFrame(t, busDevice, "single"))
+	bus.publish(buttonFrame(t, busDevice, "single"))
+	want := []string{"once", "always", "last", "always"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+	// subscribing again must not bring back the removed subscribers
+	bus.subscribe(BUTTON, func(eventWrapper EventWrapper) {
+		got = append(got, "new")
+	}, nil)
+	got = nil
+	bus.publish(buttonFrame(t, busDevice, "single"))
+	if want := []string{"always", "new"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestSourceFilterMatchesInteractionsOfDevice(t *testing.T) {
+	interactionUri := InteractionName("hello") + DEVICE_PATTERN + url.PathEscape(busDevice)
+	for _, test := range []struct {
+		filter    string
+		sourceUri string
+		want      bool
+	}{
+		{busDevice, busDevice, true},
+		{busDevice, interactionUri, true},
+		{busDevice, busOtherDevice, false},
+		{interactionUri, interactionUri, true},
+		{interactionUri, busDevice, false},
+	} {
+		if got := SourceFilter(test.filter)(buttonFrame(t, test.sourceUri, "single")); got != test.want {
+			t.Errorf("SourceFilter(%s) of %s = %v, want %v", test.filter, test.sourceUri, got, test.want)
+		}
+	}
+	timer := responseFrame(t, map[string]interface{}{"_type": "wf_api_timer_fired_event", "name": "reminder"})
+	if !TimerFilter("reminder")(timer) || TimerFilter("other")(timer) {
+		t.Error("TimerFilter matches the wrong timers")
+	}
+}
+
+// SubscribeEvent decodes the events for its subscriber, and skips those that don't decode.
+func TestSubscribeEvent(t *testing.T) {
+	wfInst := newTestInstance()
+	var got []ButtonEvent
+	SubscribeEvent(wfInst, BUTTON, func(buttonEvent ButtonEvent) {
+		got = append(got, buttonEvent)
+	}, ButtonFilter("", "double"))
+
+	wfInst.Subscriptions.publish(buttonFrame(t, busDevice, "single"))
+	wfInst.Subscriptions.publish(buttonFrame(t, busDevice, "double"))
+	// button is not a string, so the event does not decode
+	wfInst.Subscriptions.publish(responseFrame(t, map[string]interface{}{
+		"_type": "wf_api_button_event", "source_uri": busDevice, "button": 5, "taps": "double",
+	}))
+
+	want := []ButtonEvent{{SourceUri: busDevice, Button: "action", Taps: "double"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+}
@@ -0,0 +1,127 @@
+// Copyright © 2022 Relay Inc.
+
+package sdk
+
+import (
+	"net/url"
+	"strings"
+	"sync"
+)
+
+// How events are ordered when handlers run concurrently, see WithConcurrentHandlers.
+type HandlerOrdering string
+
+const (
+	// Events from the same device are handled one at a time, in the order they arrived.
+	// Events from an interaction count as events from the interaction's device.
+	ORDER_BY_SOURCE HandlerOrdering = "source"
+
+	// Events with the same source URI are handled one at a time, in the order they arrived,
+	// so each interaction on a device is ordered separately.
+	ORDER_BY_INTERACTION HandlerOrdering = "interaction"
+)
+
+// Runs the handlers of a workflow instance concurrently. Events are sorted into lanes by
+// their source, each lane handles its events in order on its own goroutine.
+type dispatcher struct {
+	wfInst   *workflowInstance
+	ordering HandlerOrdering
+	slots    chan struct{} // one per lane that is handling events
+	mutex    sync.Mutex
+	lanes    map[string][]EventWrapper
+	inFlight sync.WaitGroup
+}
+
+func newDispatcher(wfInst *workflowInstance, workers int, ordering HandlerOrdering) *dispatcher {
+	if workers < 1 {
+		workers = 1
+	}
+	return &dispatcher{
+		wfInst:   wfInst,
+		ordering: ordering,
+		slots:    make(chan struct{}, workers),
+		lanes:    make(map[string][]EventWrapper),
+	}
+}
+
+// Hands the event to the lane of its source. An event for a lane that is already handling
+// events waits in the lane, without taking a worker. Blocks while the maximum number of lanes
+// is handling events, so a full event queue applies its overflow policy. START and STOP
+// events are handled once all earlier events are, and before any later one.
+func (dispatcher *dispatcher) dispatch(eventWrapper EventWrapper) {
+	if eventWrapper.EventName == START || eventWrapper.EventName == STOP {
+		dispatcher.wait()
+		dispatcher.wfInst.handleEvent(eventWrapper)
+		return
+	}
+
+	key := dispatcher.laneKey(eventWrapper)
+	if dispatcher.enqueue(key, eventWrapper) {
+		return
+	}
+	// a new lane needs a worker; dispatch is only called by the event loop, so no one else
+	// can start the lane in the meantime
+	dispatcher.slots <- struct{}{}
+	dispatcher.mutex.Lock()
+	defer dispatcher.mutex.Unlock()
+	dispatcher.lanes[key] = []EventWrapper{eventWrapper}
+	dispatcher.inFlight.Add(1)
+	go dispatcher.runLane(key)
+}
+
+// appends the event to the lane, if the lane is handling events. Returns false if it is not.
+func (dispatcher *dispatcher) enqueue(key string, eventWrapper EventWrapper) bool {
+	dispatcher.mutex.Lock()
+	defer dispatcher.mutex.Unlock()
+	queued, running := dispatcher.lanes[key]
+	if running {
+		dispatcher.lanes[key] = append(queued, eventWrapper)
+	}
+	return running
+}
+
+// handles the events of a lane until it is empty, then gives its worker back
+func (dispatcher *dispatcher) runLane(key string) {
+	defer dispatcher.inFlight.Done()
+	for {
+		dispatcher.mutex.Lock()
+		queued := dispatcher.lanes[key]
+		if len(queued) == 0 {
+			delete(dispatcher.lanes, key)
+			dispatcher.mutex.Unlock()
+			<-dispatcher.slots
+			return
+		}
+		eventWrapper := queued[0]
+		dispatcher.lanes[key] = queued[1:]
+		dispatcher.mutex.Unlock()
+
+		dispatcher.wfInst.handleEvent(eventWrapper)
+	}
+}
+
+// blocks until every dispatched event has been handled
+func (dispatcher *dispatcher) wait() {
+	dispatcher.inFlight.Wait()
+}
+
+// Events without a source, i.e. timers, share a lane.
+func (dispatcher *dispatcher) laneKey(eventWrapper EventWrapper) string {
+	sourceUri, _ := eventWrapper.ParsedMsg["source_uri"].(string)
+	if dispatcher.ordering == ORDER_BY_INTERACTION {
+		return sourceUri
+	}
+	return deviceOf(sourceUri)
+}
+
+// Returns the device URN of an interaction URN, or the URN itself if it is not one.
+func deviceOf(uri string) string {
+	uriUnescaped, err := url.PathUnescape(uri)
+	if err != nil || !IsInteractionUri(uriUnescaped) {
+		return uri
+	}
+	if i := strings.Index(uriUnescaped, DEVICE_PATTERN); i >= 0 {
+		return uriUnescaped[i+len(DEVICE_PATTERN):]
+	}
+	return uri
+}
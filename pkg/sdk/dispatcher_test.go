@@ -0,0 +1,60 @@
+// Copyright © 2022 Relay Inc.
+
+package sdk_test
+
+import (
+	"testing"
+	"time"
+
+	"relay-go/pkg/relaytest"
+	"relay-go/pkg/sdk"
+)
+
+// A handler blocked on one device, with more events of that device queued behind it, must not
+// keep the other devices' events from being handled.
+func TestDispatcherBlockedSourceHoldsOneWorker(t *testing.T) {
+	server := relaytest.NewServer(sdk.WithConcurrentHandlers(2, sdk.ORDER_BY_SOURCE))
+	defer server.Close()
+	release := make(chan struct{})
+	handled := make(chan string, 10)
+	server.AddWorkflow("buttons", func(api sdk.RelayApi) {
+		api.OnButton(func(buttonEvent sdk.ButtonEvent) {
+			if buttonEvent.SourceUri == deviceA {
+				<-release
+			}
+			handled <- buttonEvent.SourceUri + " " + buttonEvent.Taps
+		})
+	})
+	session, err := server.Dial("buttons")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer session.Close()
+
+	session.SendStart(deviceA)
+	for _, taps := range []string{"single", "double", "triple"} {
+		session.SendButton(deviceA, "action", taps)
+	}
+	session.SendButton(deviceB, "action", "single")
+
+	select {
+	case got := <-handled:
+		if got != deviceB+" single" {
+			t.Fatalf("handled %q first, want the event of %s", got, deviceB)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("the event of the second device was not handled while the first one was blocked")
+	}
+
+	close(release)
+	for _, taps := range []string{"single", "double", "triple"} {
+		select {
+		case got := <-handled:
+			if want := deviceA + " " + taps; got != want {
+				t.Fatalf("handled %q, want %q", got, want)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatalf("the %s event of %s was not handled", taps, deviceA)
+		}
+	}
+}
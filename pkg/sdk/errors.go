@@ -0,0 +1,103 @@
+// Copyright © 2022 Relay Inc.
+
+package sdk
+
+import (
+	"encoding/json"
+	"errors"
+)
+
+// Returned when the Relay server does not respond to a request in time.
+var ErrRequestTimeout = errors.New("request timeout")
+
+// Returned for requests that are pending, or made, after the workflow instance stopped.
+var ErrWorkflowStopped = errors.New("workflow instance stopped")
+
+// Passed to the OnDisconnect handler when the websocket closed without a STOP event, i.e.
+// because the Relay server stopped answering pings.  The error wraps it.
+var ErrConnectionLost = errors.New("websocket connection lost")
+
+// Returned when the Relay server answers a request with an error response.
+var ErrServerError = errors.New("relay server returned an error")
+
+// Returned by the Targets variants of the api when the list of targets is empty.  Nothing is
+// sent.
+var ErrNoTargets = errors.New("no targets")
+
+// Matches a FrameError with errors.Is.
+var ErrMalformedFrame = errors.New("malformed frame")
+
+// A FrameError is returned for a websocket frame from the Relay server that is not a valid
+// event or response, i.e. invalid JSON or a missing _type.  The frame is skipped.
+type FrameError struct {
+	Reason string
+	Frame  []byte
+	Err    error // the JSON decoding error, if any
+}
+
+func (frameError *FrameError) Error() string {
+	if frameError.Err != nil {
+		return "malformed frame: " + frameError.Reason + ": " + frameError.Err.Error()
+	}
+	return "malformed frame: " + frameError.Reason
+}
+
+func (frameError *FrameError) Is(target error) bool {
+	return target == ErrMalformedFrame
+}
+
+func (frameError *FrameError) Unwrap() error {
+	return frameError.Err
+}
+
+// The Code of the error response the Relay server ends a listen with when the user did not
+// speak before the listen's timeout.
+const LISTEN_TIMEOUT_CODE = "timeout"
+
+// A RelayError is an error response sent by the Relay server when it rejects a request,
+// i.e. because of a malformed URN or the wrong type of target.  It matches ErrServerError
+// with errors.Is.
+type RelayError struct {
+	Id          string // the id of the rejected request
+	Code        string
+	Message     string
+	RequestType string // the _type of the rejected request, if the server sent it
+}
+
+func (relayError *RelayError) Error() string {
+	msg := "relay server error"
+	if relayError.Code != "" {
+		msg += " " + relayError.Code
+	}
+	if relayError.RequestType != "" {
+		msg += " for " + relayError.RequestType
+	}
+	if relayError.Message != "" {
+		msg += ": " + relayError.Message
+	}
+	return msg
+}
+
+func (relayError *RelayError) Is(target error) bool {
+	return target == ErrServerError
+}
+
+// The wf_api_error_response the Relay server answers a rejected request with, also sent as
+// a wf_api_error_event.
+type errorMessage struct {
+	Type        string `json:"_type"`
+	Id          string `json:"_id"`
+	Code        string `json:"code"`
+	Message     string `json:"message"`
+	RequestType string `json:"request_type"`
+}
+
+// decodes a wf_api_error_response or wf_api_error_event into a RelayError
+func errorResponse(eventWrapper EventWrapper) *RelayError {
+	var msg errorMessage
+	if err := json.Unmarshal(eventWrapper.Msg, &msg); err != nil {
+		id, _ := eventWrapper.ParsedMsg["_id"].(string)
+		return &RelayError{Id: id, Message: "undecodable error response: " + err.Error()}
+	}
+	return &RelayError{Id: msg.Id, Code: msg.Code, Message: msg.Message, RequestType: msg.RequestType}
+}
@@ -0,0 +1,87 @@
+// Copyright © 2022 Relay Inc.
+
+package sdk
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	log "github.com/sirupsen/logrus"
+)
+
+func TestErrorResponse(t *testing.T) {
+	for _, test := range []struct {
+		frame string
+		want  RelayError
+	}{{
+		frame: `{"_type":"wf_api_error_response","_id":"b2c4e1a0","code":"invalid_target","message":"target urn:relay-resource:name:device:nobody is not a device","request_type":"wf_api_say_request"}`,
+		want:  RelayError{Id: "b2c4e1a0", Code: "invalid_target", Message: "target urn:relay-resource:name:device:nobody is not a device", RequestType: "wf_api_say_request"},
+	}, {
+		frame: `{"_type":"wf_api_error_event","code":"timeout","message":"listen timed out"}`,
+		want:  RelayError{Code: "timeout", Message: "listen timed out"},
+	}} {
+		eventWrapper, _ := parseFrame(t, test.frame)
+		got := errorResponse(eventWrapper)
+		if *got != test.want {
+			t.Errorf("%s decoded to %+v, want %+v", test.frame, *got, test.want)
+		}
+		if !errors.Is(got, ErrServerError) {
+			t.Errorf("%v does not match ErrServerError", got)
+		}
+	}
+}
+
+// An error response that does not have the documented shape still fails the request.
+func TestUndecodableErrorResponse(t *testing.T) {
+	eventWrapper, _ := parseFrame(t, `{"_type":"wf_api_error_response","_id":"7","code":404,"message":"not found"}`)
+	got := errorResponse(eventWrapper)
+	if got.Id != "7" || got.Code != "" || !strings.HasPrefix(got.Message, "undecodable error response: ") {
+		t.Errorf("decoded to %+v", *got)
+	}
+}
+
+// An error response completes the call it answers with the RelayError.
+func TestErrorResponseCompletesCall(t *testing.T) {
+	wfInst := newTestInstance()
+	call := &Call{Done: make(chan bool, 1)}
+	wfInst.Pending["b2c4e1a0"] = call
+	eventWrapper, eventName := parseFrame(t, `{"_type":"wf_api_error_response","_id":"b2c4e1a0","code":"invalid_target","message":"not a device","request_type":"wf_api_say_request"}`)
+	if eventName != ERROR {
+		t.Fatalf("parsed as %s, want %s", eventName, ERROR)
+	}
+	wfInst.handleErrorResponse(eventWrapper)
+	select {
+	case <-call.Done:
+	default:
+		t.Fatal("the call was not completed")
+	}
+	relayError := errorResponse(call.EventWrapper)
+	if relayError.Code != "invalid_target" || relayError.RequestType != "wf_api_say_request" {
+		t.Errorf("the call failed with %v", relayError)
+	}
+}
+
+func parseFrame(t *testing.T, frame string) (EventWrapper, Event) {
+	t.Helper()
+	parsedMsg, eventName, _, err := parseMessage([]byte(frame))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return EventWrapper{ParsedMsg: parsedMsg, Msg: []byte(frame), EventName: eventName}, eventName
+}
+
+func newTestInstance() *workflowInstance {
+	logger := log.New()
+	logger.SetOutput(io.Discard)
+	ctx, cancel := context.WithCancel(context.Background())
+	return &workflowInstance{
+		Pending: make(map[string]*Call),
+		Prompts: make(map[string]chan struct{}),
+		Logger:  logger.WithField("workflow", "test"),
+		Ctx:     ctx,
+		Cancel:  cancel,
+	}
+}
@@ -0,0 +1,249 @@
+// Copyright © 2022 Relay Inc.
+
+package sdk
+
+import (
+	"sync"
+)
+
+// What a workflow instance's event queue does with an event that arrives while the queue
+// is full, see WithEventQueue.
+type OverflowPolicy string
+
+const (
+	// The queue grows as needed, no event is ever dropped. This is the default.
+	OVERFLOW_UNBOUNDED OverflowPolicy = "unbounded"
+
+	// Stops reading from the websocket until the workflow has handled an event, which
+	// applies backpressure to the Relay server. The queue still grows while the workflow is
+	// waiting for a response, as blocking then would stall the workflow until the request
+	// times out. The time spent blocked does not count towards the read deadline.
+	OVERFLOW_BLOCK OverflowPolicy = "block"
+
+	// Drops the oldest queued event to make room for the new one. START and STOP events are
+	// never dropped.
+	OVERFLOW_DROP_OLDEST OverflowPolicy = "drop_oldest"
+
+	// Drops an event of one of the droppable types given to WithEventQueue: the new event if
+	// it is droppable, otherwise the oldest queued droppable event. If there is none, the
+	// queue blocks as with OVERFLOW_BLOCK.
+	OVERFLOW_DROP_BY_TYPE OverflowPolicy = "drop_by_type"
+)
+
+// A snapshot of the event queue of a workflow instance.
+type EventQueueStats struct {
+	Workflow  string // the name of the workflow
+	Depth     int    // the number of queued events
+	Capacity  int    // the capacity of the queue, 0 if it is unbounded
+	HighWater int    // the highest depth the queue has reached
+	Dropped   uint64 // the number of events dropped because the queue was full
+}
+
+// Queues the events read from the websocket until the workflow handles them.
+type eventQueue struct {
+	mutex     sync.Mutex
+	events    []EventWrapper
+	capacity  int
+	policy    OverflowPolicy
+	droppable map[Event]bool
+	highWater int
+	dropped   uint64
+	workflow  string
+
+	canBlock func() bool                 // reports whether blocking the reader is safe
+	divert   func(*EventWrapper) bool    // offers the event to a waiter, true if it needs no queueing
+	onStats  func(stats EventQueueStats) // called after each event is queued or dropped
+	onBlock  func(blocked bool)          // called when push starts and stops waiting for space
+
+	ready     chan struct{} // signalled when an event is queued
+	space     chan struct{} // signalled when an event is taken, or blocking should be reconsidered
+	closed    chan struct{}
+	closeOnce sync.Once
+}
+
+func newEventQueue(workflow string, capacity int, policy OverflowPolicy, droppable []Event) *eventQueue {
+	if policy == "" || capacity <= 0 {
+		policy = OVERFLOW_UNBOUNDED
+	}
+	if policy == OVERFLOW_UNBOUNDED {
+		capacity = 0
+	}
+	queue := &eventQueue{
+		capacity:  capacity,
+		policy:    policy,
+		droppable: make(map[Event]bool),
+		workflow:  workflow,
+		canBlock:  func() bool { return true },
+		ready:     make(chan struct{}, 1),
+		space:     make(chan struct{}, 1),
+		closed:    make(chan struct{}),
+	}
+	for _, event := range droppable {
+		queue.droppable[event] = true
+	}
+	return queue
+}
+
+// Queues the event, applying the overflow policy if the queue is full. Returns once the
+// event was queued or dropped, or the queue was closed, and whether an event was dropped.
+func (queue *eventQueue) push(eventWrapper EventWrapper) bool {
+	blocked := false
+	defer func() {
+		if blocked && queue.onBlock != nil {
+			queue.onBlock(false)
+		}
+	}()
+	for {
+		queue.mutex.Lock()
+		select {
+		case <-queue.closed:
+			queue.mutex.Unlock()
+			return false
+		default:
+		}
+		// checked under the lock, so a waiter registering meanwhile either gets the event
+		// here or finds it queued
+		if queue.divert != nil && queue.divert(&eventWrapper) {
+			queue.mutex.Unlock()
+			return false
+		}
+		dropped := queue.dropped
+		if queue.offer(eventWrapper) {
+			stats := queue.statsLocked()
+			queue.mutex.Unlock()
+			signal(queue.ready)
+			if queue.onStats != nil {
+				queue.onStats(stats)
+			}
+			return stats.Dropped != dropped
+		}
+		queue.mutex.Unlock()
+
+		// full, wait for the workflow to take an event
+		if !blocked && queue.onBlock != nil {
+			queue.onBlock(true)
+		}
+		blocked = true
+		select {
+		case <-queue.space:
+		case <-queue.closed:
+			return false
+		}
+	}
+}
+
+// Adds the event to the queue, or drops an event, as the policy requires. Returns false if
+// the caller has to wait for space. Must be called with the mutex held.
+func (queue *eventQueue) offer(eventWrapper EventWrapper) bool {
+	// a STOP event is never held back, the workflow has to see it
+	if queue.capacity == 0 || len(queue.events) < queue.capacity || eventWrapper.EventName == STOP {
+		queue.append(eventWrapper)
+		return true
+	}
+	switch queue.policy {
+	case OVERFLOW_DROP_OLDEST:
+		for i, queued := range queue.events {
+			if !lifecycleEvent(queued.EventName) {
+				queue.drop(i)
+				queue.append(eventWrapper)
+				return true
+			}
+		}
+	case OVERFLOW_DROP_BY_TYPE:
+		if queue.droppable[eventWrapper.EventName] && !lifecycleEvent(eventWrapper.EventName) {
+			queue.dropped++
+			return true
+		}
+		for i, queued := range queue.events {
+			if queue.droppable[queued.EventName] && !lifecycleEvent(queued.EventName) {
+				queue.drop(i)
+				queue.append(eventWrapper)
+				return true
+			}
+		}
+	}
+	if !queue.canBlock() {
+		queue.append(eventWrapper)
+		return true
+	}
+	return false
+}
+
+func (queue *eventQueue) append(eventWrapper EventWrapper) {
+	queue.events = append(queue.events, eventWrapper)
+	if len(queue.events) > queue.highWater {
+		queue.highWater = len(queue.events)
+	}
+}
+
+func (queue *eventQueue) drop(i int) {
+	queue.events = append(queue.events[:i], queue.events[i+1:]...)
+	queue.dropped++
+}
+
+// Takes the oldest event from the queue. Returns false if the queue is empty.
+func (queue *eventQueue) pop() (EventWrapper, bool) {
+	queue.mutex.Lock()
+	defer queue.mutex.Unlock()
+	if len(queue.events) == 0 {
+		return EventWrapper{}, false
+	}
+	eventWrapper := queue.events[0]
+	queue.events[0] = EventWrapper{}
+	queue.events = queue.events[1:]
+	signal(queue.space)
+	return eventWrapper, true
+}
+
+// Calls fn with the queue locked, so no event is queued or taken meanwhile. fn may update the
+// queued events in place, and returns the index of one to remove, or -1.
+func (queue *eventQueue) withEvents(fn func(events []EventWrapper) int) {
+	queue.mutex.Lock()
+	defer queue.mutex.Unlock()
+	if i := fn(queue.events); i >= 0 {
+		queue.events = append(queue.events[:i], queue.events[i+1:]...)
+		signal(queue.space)
+	}
+}
+
+// Makes a blocked push check again whether it may keep blocking, i.e. after the workflow
+// sent a request whose response may be stuck behind it.
+func (queue *eventQueue) wake() {
+	signal(queue.space)
+}
+
+// Releases a blocked push and discards any later events, once nobody takes them anymore.
+func (queue *eventQueue) close() {
+	queue.closeOnce.Do(func() {
+		close(queue.closed)
+	})
+}
+
+func (queue *eventQueue) stats() EventQueueStats {
+	queue.mutex.Lock()
+	defer queue.mutex.Unlock()
+	return queue.statsLocked()
+}
+
+func (queue *eventQueue) statsLocked() EventQueueStats {
+	return EventQueueStats{
+		Workflow:  queue.workflow,
+		Depth:     len(queue.events),
+		Capacity:  queue.capacity,
+		HighWater: queue.highWater,
+		Dropped:   queue.dropped,
+	}
+}
+
+// START and STOP events are never dropped
+func lifecycleEvent(event Event) bool {
+	return event == START || event == STOP
+}
+
+// non-blocking send on a channel with a buffer of one
+func signal(ch chan struct{}) {
+	select {
+	case ch <- struct{}{}:
+	default:
+	}
+}
@@ -0,0 +1,131 @@
+// Copyright © 2022 Relay Inc.
+
+package sdk_test
+
+import (
+	"testing"
+	"time"
+
+	"relay-go/pkg/relaytest"
+	"relay-go/pkg/sdk"
+)
+
+// A full queue with OVERFLOW_DROP_BY_TYPE drops the droppable events, new or queued, to make
+// room for the others.
+func TestQueueDropsByType(t *testing.T) {
+	queued := make(chan sdk.EventQueueStats, 10)
+	server := relaytest.NewServer(
+		sdk.WithEventQueue(1, sdk.OVERFLOW_DROP_BY_TYPE, sdk.BUTTON),
+		sdk.WithEventQueueMetrics(func(stats sdk.EventQueueStats) {
+			queued <- stats
+		}),
+	)
+	defer server.Close()
+	release := make(chan struct{})
+	handled := make(chan string, 10)
+	server.AddWorkflow("slow", func(api sdk.RelayApi) {
+		api.OnButton(func(buttonEvent sdk.ButtonEvent) {
+			handled <- buttonEvent.Taps
+			<-release
+		})
+		api.OnTimerFired(func(timerFiredEvent sdk.TimerFiredEvent) {
+			handled <- timerFiredEvent.Name
+		})
+	})
+	session, err := server.Dial("slow")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer session.Close()
+	handledNext := func(want string) {
+		t.Helper()
+		select {
+		case got := <-handled:
+			if got != want {
+				t.Fatalf("handled %s, want %s", got, want)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatalf("%s was not handled", want)
+		}
+	}
+
+	// the handler holds the first button, the queue is empty meanwhile
+	session.SendButton(deviceA, "action", "single")
+	<-queued
+	handledNext("single")
+
+	// the second button is queued, the next two are dropped, and the timer replaces the
+	// queued button
+	session.SendButton(deviceA, "action", "double")
+	session.SendButton(deviceA, "action", "triple")
+	session.SendButton(deviceA, "action", "long")
+	session.SendTimerFired("reminder")
+	var stats sdk.EventQueueStats
+	for i := 0; i < 4; i++ {
+		select {
+		case stats = <-queued:
+		case <-time.After(5 * time.Second):
+			t.Fatalf("%d of 4 events were queued", i)
+		}
+	}
+	if stats.Dropped != 3 || stats.Depth != 1 || stats.Capacity != 1 {
+		t.Errorf("got %+v, want 3 dropped and 1 queued", stats)
+	}
+	close(release)
+	handledNext("reminder")
+}
+
+// A reader blocked by a full queue for longer than the read deadline must not take the Relay
+// server for dead, it cannot read the pongs meanwhile.
+func TestBlockedQueueKeepsConnection(t *testing.T) {
+	server := relaytest.NewServer(
+		sdk.WithEventQueue(1, sdk.OVERFLOW_BLOCK),
+		sdk.WithKeepalive(20*time.Millisecond, 30*time.Millisecond),
+	)
+	defer server.Close()
+	release := make(chan struct{})
+	handled := make(chan string, 10)
+	disconnected := make(chan error, 1)
+	server.AddWorkflow("slow", func(api sdk.RelayApi) {
+		api.OnButton(func(buttonEvent sdk.ButtonEvent) {
+			if buttonEvent.Taps == "single" {
+				<-release
+			}
+			handled <- buttonEvent.Taps
+		})
+		api.OnDisconnect(func(err error) {
+			disconnected <- err
+		})
+	})
+	session, err := server.Dial("slow")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer session.Close()
+
+	session.SendStart(deviceA)
+	// the first is handled, the second queued, the third blocks the reader
+	for _, taps := range []string{"single", "double", "triple"} {
+		session.SendButton(deviceA, "action", taps)
+	}
+	select {
+	case err := <-disconnected:
+		t.Fatal("disconnected while blocked: ", err)
+	case <-time.After(300 * time.Millisecond):
+	}
+	close(release)
+	session.SendButton(deviceA, "action", "long")
+
+	for _, taps := range []string{"single", "double", "triple", "long"} {
+		select {
+		case got := <-handled:
+			if got != taps {
+				t.Fatalf("handled %q, want %q", got, taps)
+			}
+		case err := <-disconnected:
+			t.Fatal("disconnected after blocking: ", err)
+		case <-time.After(5 * time.Second):
+			t.Fatalf("the %s event was not handled", taps)
+		}
+	}
+}
@@ -0,0 +1,102 @@
+// Copyright © 2022 Relay Inc.
+
+package sdk_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"relay-go/pkg/relaytest"
+	"relay-go/pkg/sdk"
+)
+
+// Each event is dispatched to its handler, decoded, and an event without a handler of its own
+// to OnUnknownEvent.
+func TestEventsReachTheirHandlers(t *testing.T) {
+	server := relaytest.NewServer()
+	defer server.Close()
+	got := make(chan string, 10)
+	server.AddWorkflow("events", func(api sdk.RelayApi) {
+		api.OnProgress(func(progressEvent sdk.ProgressEvent) {
+			got <- fmt.Sprint("progress ", progressEvent.Fields["id"], " ", progressEvent.Fields["_type"])
+		})
+		api.OnPlayInboxMessages(func(playInboxMessagesEvent sdk.PlayInboxMessagesEvent) {
+			got <- "play inbox messages " + playInboxMessagesEvent.Action
+		})
+		api.OnCallProgressing(func(callProgressingEvent sdk.CallProgressingEvent) {
+			got <- "call progressing " + callProgressingEvent.CallId + " " + callProgressingEvent.DeviceName
+		})
+		api.OnSms(func(smsEvent sdk.SmsEvent) {
+			got <- "sms " + smsEvent.Id + " " + smsEvent.Event
+		})
+		api.OnResume(func(resumeEvent sdk.ResumeEvent) {
+			got <- fmt.Sprint("resume ", resumeEvent.Fields["trigger"])
+		})
+		api.OnError(func(relayError *sdk.RelayError) {
+			got <- "error " + relayError.Code + " " + relayError.Message + " " + relayError.RequestType
+		})
+		api.OnUnknownEvent(func(eventWrapper sdk.EventWrapper) {
+			got <- fmt.Sprint("unknown ", eventWrapper.EventName, " ", eventWrapper.ParsedMsg["what"])
+		})
+	})
+	session, err := server.Dial("events")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer session.Close()
+
+	for _, test := range []struct {
+		event  sdk.Event
+		fields map[string]interface{}
+		want   string
+	}{
+		{
+			sdk.PROGRESS, map[string]interface{}{"id": "say-1"},
+			"progress say-1 wf_api_progress_event",
+		},
+		{
+			sdk.PLAY_INBOX_MESSAGES, map[string]interface{}{"action": "started"},
+			"play inbox messages started",
+		},
+		{
+			sdk.CALL_PROGRESSING, map[string]interface{}{"call_id": "call-1", "device_name": "alice", "direction": "outbound"},
+			"call progressing call-1 alice",
+		},
+		{
+			sdk.SMS, map[string]interface{}{"id": "sms-1", "event": "delivered"},
+			"sms sms-1 delivered",
+		},
+		{
+			sdk.RESUME, map[string]interface{}{"trigger": "http"},
+			"resume http",
+		},
+		{
+			// an error response that answers no pending request
+			sdk.ERROR, map[string]interface{}{"_type": "wf_api_error_response", "code": "invalid_urn", "message": "bad uri", "request_type": "wf_api_say_request"},
+			"error invalid_urn bad uri wf_api_say_request",
+		},
+		{
+			"mystery", map[string]interface{}{"what": "new"},
+			"unknown mystery new",
+		},
+	} {
+		send := session.SendEvent
+		if test.event == sdk.ERROR {
+			send = func(event sdk.Event, fields map[string]interface{}) error {
+				return session.Send(fields)
+			}
+		}
+		if err := send(test.event, test.fields); err != nil {
+			t.Fatal(err)
+		}
+		select {
+		case event := <-got:
+			if event != test.want {
+				t.Errorf("for %s, got %q, want %q", test.event, event, test.want)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatalf("the %s event was not handled", test.event)
+		}
+	}
+}
@@ -0,0 +1,106 @@
+// Copyright © 2022 Relay Inc.
+
+package sdk
+
+import (
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// Default keepalive settings of the websocket connections, see WithKeepalive and
+// WithDeadlines.
+const (
+	DEFAULT_PING_INTERVAL = 30 * time.Second
+	DEFAULT_PONG_TIMEOUT  = 10 * time.Second
+	DEFAULT_WRITE_TIMEOUT = 10 * time.Second
+)
+
+// The keepalive settings of a workflow instance's websocket.
+type keepalive struct {
+	pingInterval time.Duration
+	pongTimeout  time.Duration
+	readTimeout  time.Duration
+	writeTimeout time.Duration
+}
+
+// how long the connection may stay silent before it is considered dead, 0 for no limit
+func (keepalive keepalive) readDeadline() time.Duration {
+	if keepalive.readTimeout > 0 {
+		return keepalive.readTimeout
+	}
+	if keepalive.pingInterval > 0 {
+		return keepalive.pingInterval + keepalive.pongTimeout
+	}
+	return 0
+}
+
+// Sets the read deadline, extends it on every pong, and starts pinging the Relay server.
+// The pings stop when the websocket is closed.
+func (wfInst *workflowInstance) startKeepalive() {
+	conn := wfInst.WebsocketConnection
+	wfInst.extendReadDeadline()
+	conn.SetPongHandler(func(string) error {
+		wfInst.extendReadDeadline()
+		return nil
+	})
+	if wfInst.Keepalive.pingInterval > 0 {
+		go wfInst.ping()
+	}
+}
+
+// called whenever anything is received from the Relay server
+func (wfInst *workflowInstance) extendReadDeadline() {
+	if timeout := wfInst.Keepalive.readDeadline(); timeout > 0 {
+		wfInst.WebsocketConnection.SetReadDeadline(time.Now().Add(timeout))
+	}
+}
+
+// Called by the event queue when it stops reading from the websocket to apply backpressure,
+// and when reading resumes.  Pongs are only seen while reading, so the read deadline is lifted
+// in between, and the time spent blocked does not count as silence of the Relay server.
+func (wfInst *workflowInstance) readBlocked(blocked bool) {
+	if wfInst.Keepalive.readDeadline() <= 0 {
+		return
+	}
+	if blocked {
+		wfInst.WebsocketConnection.SetReadDeadline(time.Time{})
+	} else {
+		wfInst.extendReadDeadline()
+	}
+}
+
+func (wfInst *workflowInstance) ping() {
+	ticker := time.NewTicker(wfInst.Keepalive.pingInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			err := wfInst.WebsocketConnection.WriteControl(websocket.PingMessage, nil, wfInst.writeDeadline())
+			if err != nil {
+				wfInst.Logger.Debug("error sending ping ", err)
+				return
+			}
+		case <-wfInst.Disconnected:
+			return
+		}
+	}
+}
+
+// the deadline for a write started now, the zero time if writes have no deadline
+func (wfInst *workflowInstance) writeDeadline() time.Time {
+	if wfInst.Keepalive.writeTimeout > 0 {
+		return time.Now().Add(wfInst.Keepalive.writeTimeout)
+	}
+	return time.Time{}
+}
+
+// Called on the event goroutine once the websocket is gone, if it closed without a STOP
+// event, i.e. because the Relay server stopped answering pings.
+func (wfInst *workflowInstance) disconnected(err error) {
+	defer wfInst.recoverPanic()
+	wfInst.Logger.Warn("websocket disconnected: ", err)
+	if wfInst.OnDisconnectHandler != nil {
+		wfInst.OnDisconnectHandler(err)
+	}
+}
@@ -0,0 +1,165 @@
+// Copyright © 2022 Relay Inc.
+
+package sdk_test
+
+import (
+	"errors"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"relay-go/pkg/relaytest"
+	"relay-go/pkg/sdk"
+)
+
+// A Relay server that stops reading, and so stops answering pings, is taken for dead: the
+// instance is torn down, its pending requests fail, and the OnDisconnect handler is called.
+func TestDeadPeerDisconnects(t *testing.T) {
+	server := relaytest.NewServer(sdk.WithKeepalive(20*time.Millisecond, 30*time.Millisecond))
+	defer server.Close()
+	pending := make(chan error, 1)
+	disconnected := make(chan error, 1)
+	afterDisconnect := make(chan error, 1)
+	server.AddWorkflow("dead", func(api sdk.RelayApi) {
+		api.OnStart(func(startEvent sdk.StartEvent) {
+			// never answered
+			_, err := api.GetVarCtx(api.Context(), "name", "")
+			pending <- err
+		})
+		api.OnDisconnect(func(err error) {
+			disconnected <- err
+			_, err = api.GetVarCtx(api.Context(), "name", "")
+			afterDisconnect <- err
+		})
+	})
+	// a raw websocket, which only answers pings while it is read
+	conn, _, err := websocket.DefaultDialer.Dial(server.WorkflowURL("dead"), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	start := map[string]interface{}{
+		"_type":   "wf_api_start_event",
+		"trigger": map[string]interface{}{"type": sdk.BUTTON_TRIGGER, "args": map[string]interface{}{"source_uri": deviceA}},
+	}
+	if err := conn.WriteJSON(start); err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case err := <-pending:
+		if !errors.Is(err, sdk.ErrWorkflowStopped) {
+			t.Errorf("the pending request failed with %v, want ErrWorkflowStopped", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("the pending request did not fail")
+	}
+	select {
+	case err := <-disconnected:
+		if !errors.Is(err, sdk.ErrConnectionLost) {
+			t.Errorf("OnDisconnect got %v, want ErrConnectionLost", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("OnDisconnect was not called")
+	}
+	select {
+	case err := <-afterDisconnect:
+		if !errors.Is(err, sdk.ErrWorkflowStopped) {
+			t.Errorf("a request after the disconnect failed with %v, want ErrWorkflowStopped", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("a request after the disconnect did not fail fast")
+	}
+
+	// the instance closed its side of the websocket
+	conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	for {
+		if _, _, err = conn.ReadMessage(); err != nil {
+			break
+		}
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		t.Error("the instance did not close the websocket")
+	}
+}
+
+// A write that times out because the Relay server stopped reading fails its request, closes
+// the websocket, and calls the OnDisconnect handler.
+func TestWriteTimeoutDisconnects(t *testing.T) {
+	server := relaytest.NewServer(sdk.WithKeepalive(0, 0), sdk.WithDeadlines(0, 50*time.Millisecond))
+	defer server.Close()
+	const requests = 32
+	failed := make(chan error, requests)
+	disconnected := make(chan error, 1)
+	server.AddWorkflow("stuck", func(api sdk.RelayApi) {
+		api.OnStart(func(startEvent sdk.StartEvent) {
+			// more than the socket buffers hold, so a write times out
+			value := strings.Repeat("x", 1<<20)
+			for i := 0; i < requests; i++ {
+				go func() {
+					_, err := api.SetVarCtx(api.Context(), "big", value)
+					failed <- err
+				}()
+			}
+		})
+		api.OnDisconnect(func(err error) {
+			disconnected <- err
+		})
+	})
+	// a raw websocket, which is never read until the write timed out
+	conn, _, err := websocket.DefaultDialer.Dial(server.WorkflowURL("stuck"), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	start := map[string]interface{}{
+		"_type":   "wf_api_start_event",
+		"trigger": map[string]interface{}{"type": sdk.BUTTON_TRIGGER, "args": map[string]interface{}{"source_uri": deviceA}},
+	}
+	if err := conn.WriteJSON(start); err != nil {
+		t.Fatal(err)
+	}
+
+	// the request whose write timed out fails with the timeout, the others as the workflow
+	// stopped
+	timedOut := 0
+	for i := 0; i < requests; i++ {
+		select {
+		case err := <-failed:
+			var netErr net.Error
+			if errors.As(err, &netErr) && netErr.Timeout() {
+				timedOut++
+			} else if !errors.Is(err, sdk.ErrWorkflowStopped) {
+				t.Errorf("a request failed with %v, want a write timeout or ErrWorkflowStopped", err)
+			}
+		case <-time.After(10 * time.Second):
+			t.Fatal("the requests did not fail")
+		}
+	}
+	if timedOut != 1 {
+		t.Errorf("%d requests failed with a write timeout, want 1", timedOut)
+	}
+	select {
+	case err := <-disconnected:
+		if !errors.Is(err, sdk.ErrConnectionLost) {
+			t.Errorf("OnDisconnect got %v, want ErrConnectionLost", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("OnDisconnect was not called")
+	}
+
+	// the instance closed its side of the websocket, behind the frames it managed to write
+	conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	for {
+		if _, _, err = conn.ReadMessage(); err != nil {
+			break
+		}
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		t.Error("the instance did not close the websocket")
+	}
+}
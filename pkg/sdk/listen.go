@@ -0,0 +1,151 @@
+// Copyright © 2022 Relay Inc.
+
+package sdk
+
+import (
+	"context"
+	"errors"
+	"strings"
+)
+
+// How a listen ended, see ListenResult.
+type ListenOutcome string
+
+const (
+	// The speech matched one of the phrases.
+	LISTEN_MATCHED ListenOutcome = "matched"
+
+	// The speech was transcribed, and no phrases were given to match it against.
+	LISTEN_RECOGNIZED ListenOutcome = "recognized"
+
+	// The speech matched none of the phrases. The transcribed text, if any, is still set.
+	LISTEN_NO_MATCH ListenOutcome = "no_match"
+
+	// The user pressed the talk button but said nothing that could be recognized.
+	LISTEN_SILENCE ListenOutcome = "silence"
+
+	// The user did not speak before the listen timed out.
+	LISTEN_TIMEOUT ListenOutcome = "timeout"
+)
+
+// The result of ListenFor.
+type ListenResult struct {
+	Outcome     ListenOutcome
+	Text        string // the recognized text
+	PhraseIndex int    // the index of the matched phrase in ListenOptions.Phrases, or -1
+	Lang        string // the detected language
+	Audio       string // a reference to the recorded audio, if the server sent one
+	SourceUri   string // the device or interaction the speech came from
+	Attempts    int    // the number of listens it took
+}
+
+// The options of ListenFor.
+type ListenOptions struct {
+	Phrases    []string // phrases to match the speech against
+	Transcribe bool     // transcribe speech that matches no phrase
+	AltLang    Language // an alternative language to recognize
+	Timeout    int      // seconds to wait for the user to speak, defaults to 30
+
+	Lang     Language // the language of Prompt and Reprompt, defaults to ENGLISH
+	Prompt   string   // said before the first listen, if set
+	Reprompt string   // said before each retry, if set
+
+	Retries int             // how often to listen again after an outcome in RetryOn, at least 0
+	RetryOn []ListenOutcome // defaults to LISTEN_TIMEOUT, LISTEN_SILENCE and LISTEN_NO_MATCH
+}
+
+func (opts ListenOptions) retryOn(outcome ListenOutcome) bool {
+	retryOn := opts.RetryOn
+	if retryOn == nil {
+		retryOn = []ListenOutcome{LISTEN_TIMEOUT, LISTEN_SILENCE, LISTEN_NO_MATCH}
+	}
+	for _, o := range retryOn {
+		if o == outcome {
+			return true
+		}
+	}
+	return false
+}
+
+// Listens for the user to speak and tells how the listen ended, i.e. whether the speech
+// matched one of the phrases or the user did not speak at all. Says opts.Prompt first, and
+// listens again up to opts.Retries times after an outcome in opts.RetryOn, saying
+// opts.Reprompt before each retry. A timeout is an outcome, not an error; returns an error
+// if a request fails otherwise, or ctx is done.
+func (wfInst *workflowInstance) ListenFor(ctx context.Context, sourceUri string, opts ListenOptions) (ListenResult, error) {
+	timeout := opts.Timeout
+	if timeout <= 0 {
+		timeout = 30
+	}
+	lang := opts.Lang
+	if lang == "" {
+		lang = ENGLISH
+	}
+	retries := opts.Retries
+	if retries < 0 {
+		retries = 0
+	}
+
+	result := ListenResult{PhraseIndex: -1}
+	for attempt := 0; attempt <= retries; attempt++ {
+		prompt := opts.Prompt
+		if attempt > 0 {
+			prompt = opts.Reprompt
+		}
+		if prompt != "" {
+			if _, err := wfInst.SayAndWaitCtx(ctx, sourceUri, prompt, lang); err != nil {
+				return result, err
+			}
+		}
+
+		speech, err := wfInst.ListenSpeech(ctx, sourceUri, opts.Phrases, opts.Transcribe, opts.AltLang, timeout)
+		if err != nil && !isListenTimeout(err) {
+			return result, err
+		}
+		result = listenResult(speech, err, opts.Phrases)
+		result.Attempts = attempt + 1
+		if !opts.retryOn(result.Outcome) {
+			break
+		}
+	}
+	return result, nil
+}
+
+// classifies the speech event, or the timeout, of a listen
+func listenResult(speech SpeechEvent, err error, phrases []string) ListenResult {
+	result := ListenResult{
+		Text:        speech.Text,
+		PhraseIndex: -1,
+		Lang:        speech.Lang,
+		Audio:       speech.Audio,
+		SourceUri:   speech.SourceUri,
+	}
+	switch {
+	case err != nil:
+		result.Outcome = LISTEN_TIMEOUT
+	case strings.TrimSpace(speech.Text) == "":
+		result.Outcome = LISTEN_SILENCE
+	case len(phrases) == 0:
+		result.Outcome = LISTEN_RECOGNIZED
+	default:
+		result.Outcome = LISTEN_NO_MATCH
+		for i, phrase := range phrases {
+			if strings.EqualFold(strings.TrimSpace(phrase), strings.TrimSpace(speech.Text)) {
+				result.Outcome = LISTEN_MATCHED
+				result.PhraseIndex = i
+				break
+			}
+		}
+	}
+	return result
+}
+
+// The server ends a listen the user did not answer with an error response with the code
+// LISTEN_TIMEOUT_CODE, and we give up waiting a little after the listen's timeout.
+func isListenTimeout(err error) bool {
+	if errors.Is(err, ErrRequestTimeout) {
+		return true
+	}
+	var relayError *RelayError
+	return errors.As(err, &relayError) && relayError.Code == LISTEN_TIMEOUT_CODE
+}
@@ -0,0 +1,140 @@
+// Copyright © 2022 Relay Inc.
+
+package sdk_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"relay-go/pkg/relaytest"
+	"relay-go/pkg/sdk"
+)
+
+// runs ListenFor with the options in a workflow, with the session set up by prepare
+func listenFor(t *testing.T, opts sdk.ListenOptions, prepare func(session *relaytest.Session)) (sdk.ListenResult, error) {
+	t.Helper()
+	server := relaytest.NewServer()
+	defer server.Close()
+	type outcome struct {
+		result sdk.ListenResult
+		err    error
+	}
+	done := make(chan outcome, 1)
+	server.AddWorkflow("listen", func(api sdk.RelayApi) {
+		api.OnStart(func(startEvent sdk.StartEvent) {
+			result, err := api.ListenFor(api.Context(), deviceA, opts)
+			done <- outcome{result, err}
+		})
+	})
+	session, err := server.Dial("listen")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer session.Close()
+	prepare(session)
+	session.SendStart(deviceA)
+	select {
+	case outcome := <-done:
+		return outcome.result, outcome.err
+	case <-time.After(5 * time.Second):
+		t.Fatal("ListenFor did not return")
+		return sdk.ListenResult{}, nil
+	}
+}
+
+func TestListenForTimeout(t *testing.T) {
+	result, err := listenFor(t, sdk.ListenOptions{Retries: 1}, func(session *relaytest.Session) {
+		session.RespondError("listen", sdk.LISTEN_TIMEOUT_CODE, "listen timed out")
+	})
+	if err != nil || result.Outcome != sdk.LISTEN_TIMEOUT || result.Attempts != 2 {
+		t.Errorf("got %+v, %v; want a timeout after 2 attempts", result, err)
+	}
+}
+
+// Only the timeout code ends a listen with LISTEN_TIMEOUT, other errors are returned even if
+// they mention a timeout.
+func TestListenForError(t *testing.T) {
+	_, err := listenFor(t, sdk.ListenOptions{}, func(session *relaytest.Session) {
+		session.RespondError("listen", "invalid_argument", "timeout must be positive")
+	})
+	var relayError *sdk.RelayError
+	if !errors.As(err, &relayError) || relayError.Code != "invalid_argument" {
+		t.Errorf("got %v, want the error response", err)
+	}
+}
+
+func TestListenForNegativeRetries(t *testing.T) {
+	result, err := listenFor(t, sdk.ListenOptions{Phrases: []string{"yes", "no"}, Retries: -1}, func(session *relaytest.Session) {
+		session.QueueSpeech("No")
+	})
+	if err != nil || result.Outcome != sdk.LISTEN_MATCHED || result.PhraseIndex != 1 || result.Attempts != 1 {
+		t.Errorf("got %+v, %v; want a match of the second phrase after 1 attempt", result, err)
+	}
+}
+
+// Listens on two devices at the same time each get the speech event carrying their own
+// request_id, whatever order the speech events come in.
+func TestConcurrentListens(t *testing.T) {
+	server := relaytest.NewServer()
+	defer server.Close()
+	type heard struct {
+		sourceUri string
+		speech    sdk.SpeechEvent
+		err       error
+	}
+	got := make(chan heard, 2)
+	server.AddWorkflow("listen", func(api sdk.RelayApi) {
+		api.OnStart(func(startEvent sdk.StartEvent) {
+			for _, sourceUri := range []string{deviceA, deviceB} {
+				future := api.Async().ListenSpeech(api.Context(), sourceUri, nil, true, sdk.ENGLISH, 30)
+				go func(sourceUri string) {
+					speech, err := future.Wait(api.Context())
+					got <- heard{sourceUri, speech, err}
+				}(sourceUri)
+			}
+		})
+	})
+	session, err := server.Dial("listen")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer session.Close()
+	// acknowledge the listens, the speech events follow below
+	session.Respond("listen", func(req relaytest.Request) []map[string]interface{} {
+		return []map[string]interface{}{{"_type": "wf_api_listen_response", "_id": req.Id}}
+	})
+	session.SendStart(deviceA)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	requestIds := make(map[string]string)
+	for i := 0; i < 2; i++ {
+		req, err := session.WaitForRequest(ctx, "listen")
+		if err != nil {
+			t.Fatal(err)
+		}
+		requestIds[req.Target[0]] = req.String("request_id")
+	}
+	if requestIds[deviceA] == "" || requestIds[deviceA] == requestIds[deviceB] {
+		t.Fatalf("the listens have the request ids %v, want distinct ones", requestIds)
+	}
+	for _, sourceUri := range []string{deviceB, deviceA} {
+		session.SendEvent(sdk.SPEECH, map[string]interface{}{
+			"request_id": requestIds[sourceUri], "text": "hello from " + sourceUri, "lang": "en-US", "source_uri": sourceUri,
+		})
+	}
+
+	for i := 0; i < 2; i++ {
+		select {
+		case heard := <-got:
+			speech := heard.speech
+			if heard.err != nil || speech.RequestId != requestIds[heard.sourceUri] || speech.SourceUri != heard.sourceUri || speech.Text != "hello from "+heard.sourceUri {
+				t.Errorf("the listen on %s got %+v, %v", heard.sourceUri, speech, heard.err)
+			}
+		case <-ctx.Done():
+			t.Fatal("the listens did not return")
+		}
+	}
+}
@@ -0,0 +1,42 @@
+// Copyright © 2022 Relay Inc.
+
+package sdk
+
+import (
+	"runtime/debug"
+)
+
+// The stop reason given to a workflow instance after a panic in its workflow function, one
+// of its handlers, or the SDK code serving it.
+const PANIC_STOP_REASON = "panic"
+
+// Describes a panic recovered in a workflow instance, see WithPanicHandler.
+type PanicInfo struct {
+	Workflow string      // the name of the workflow
+	Value    interface{} // the value passed to panic
+	Stack    []byte      // the stack trace of the panicking goroutine
+}
+
+// Deferred at the top of each goroutine of a workflow instance, so a panic only stops that
+// instance instead of crashing the process.
+func (wfInst *workflowInstance) recoverPanic() {
+	if recovered := recover(); recovered != nil {
+		wfInst.panicked(recovered, debug.Stack())
+	}
+}
+
+// reports a recovered panic and stops the workflow instance
+func (wfInst *workflowInstance) panicked(recovered interface{}, stack []byte) {
+	wfInst.Logger.WithField("stack", string(stack)).Error("panic in workflow instance: ", recovered)
+	if wfInst.PanicHandler != nil {
+		func() {
+			defer func() {
+				if recovered := recover(); recovered != nil {
+					wfInst.Logger.Error("panic in panic handler: ", recovered)
+				}
+			}()
+			wfInst.PanicHandler(PanicInfo{Workflow: wfInst.WorkflowName, Value: recovered, Stack: stack})
+		}()
+	}
+	wfInst.stop(PANIC_STOP_REASON)
+}
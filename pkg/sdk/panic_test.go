@@ -0,0 +1,97 @@
+// Copyright © 2022 Relay Inc.
+
+package sdk_test
+
+import (
+	"testing"
+	"time"
+
+	"relay-go/pkg/relaytest"
+	"relay-go/pkg/sdk"
+)
+
+// A panic in a handler stops only the instance it happened in, the other instances of the
+// workflow keep running.
+func TestPanicStopsOnlyItsInstance(t *testing.T) {
+	panics := make(chan sdk.PanicInfo, 2)
+	server := relaytest.NewServer(sdk.WithPanicHandler(func(info sdk.PanicInfo) {
+		panics <- info
+	}))
+	defer server.Close()
+	stops := make(chan string, 2)
+	buttons := make(chan string, 2)
+	server.AddWorkflow("panicky", func(api sdk.RelayApi) {
+		var sourceUri string
+		api.OnStart(func(startEvent sdk.StartEvent) {
+			sourceUri = api.GetSourceUri(startEvent)
+		})
+		api.OnButton(func(buttonEvent sdk.ButtonEvent) {
+			if buttonEvent.Taps == "triple" {
+				panic("boom")
+			}
+			value, err := api.GetVarCtx(api.Context(), "name", "")
+			if err != nil {
+				value = err.Error()
+			}
+			buttons <- buttonEvent.SourceUri + " " + value
+		})
+		api.OnStop(func(stopEvent sdk.StopEvent) {
+			stops <- sourceUri + " " + stopEvent.Reason
+		})
+	})
+
+	sessionA, err := server.Dial("panicky")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sessionA.Close()
+	sessionB, err := server.Dial("panicky")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sessionB.Close()
+	sessionB.RespondWith("get_var", map[string]interface{}{"value": "bob"})
+	sessionA.SendStart(deviceA)
+	sessionB.SendStart(deviceB)
+	sessionA.SendButton(deviceA, "action", "triple")
+
+	select {
+	case info := <-panics:
+		if info.Workflow != "panicky" || info.Value != "boom" || len(info.Stack) == 0 {
+			t.Errorf("the panic handler got %+v", info)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("the panic handler was not called")
+	}
+	select {
+	case stop := <-stops:
+		if want := deviceA + " " + sdk.PANIC_STOP_REASON; stop != want {
+			t.Errorf("got the stop %q, want %q", stop, want)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("the instance that panicked was not stopped")
+	}
+	select {
+	case <-sessionA.Done():
+	case <-time.After(5 * time.Second):
+		t.Fatal("the websocket of the instance that panicked was not closed")
+	}
+
+	// the other instance still handles events and makes requests
+	sessionB.SendButton(deviceB, "action", "single")
+	select {
+	case button := <-buttons:
+		if want := deviceB + " bob"; button != want {
+			t.Errorf("got %q, want %q", button, want)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("the other instance did not handle its button")
+	}
+	select {
+	case stop := <-stops:
+		t.Errorf("the other instance was stopped too: %q", stop)
+	case info := <-panics:
+		t.Errorf("the panic handler was called again: %+v", info)
+	default:
+	}
+}
@@ -0,0 +1,158 @@
+// Copyright © 2022 Relay Inc.
+
+package sdk_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"relay-go/pkg/relaytest"
+	"relay-go/pkg/sdk"
+)
+
+// Answers each say request with a response carrying the prompt id, and signals once it was
+// sent, so prompt events sent afterwards arrive behind it.
+func respondWithPrompt(session *relaytest.Session, promptId string, responded chan<- struct{}) {
+	session.Respond("say", func(req relaytest.Request) []map[string]interface{} {
+		session.Send(map[string]interface{}{"_type": "wf_api_say_response", "_id": req.Id, "id": promptId})
+		responded <- struct{}{}
+		return nil
+	})
+}
+
+func sendPrompt(session *relaytest.Session, promptType string, promptId string) {
+	session.SendEvent(sdk.PROMPT, map[string]interface{}{"type": promptType, "id": promptId, "source_uri": deviceA})
+}
+
+// SayAndWait returns on the stopped event of its own prompt, not on those of other prompts
+// or of other instances, which may use the same prompt ids, whatever order they come in.
+func TestSayAndWaitWaitsForItsOwnPrompt(t *testing.T) {
+	server := relaytest.NewServer()
+	defer server.Close()
+	said := make(chan string, 2)
+	server.AddWorkflow("say", func(api sdk.RelayApi) {
+		api.OnStart(func(startEvent sdk.StartEvent) {
+			sourceUri := api.GetSourceUri(startEvent)
+			if _, err := api.SayAndWaitCtx(api.Context(), sourceUri, "hello", sdk.ENGLISH); err != nil {
+				t.Error(err)
+			}
+			said <- sourceUri
+		})
+	})
+	sessionA, err := server.Dial("say")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sessionA.Close()
+	sessionB, err := server.Dial("say")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sessionB.Close()
+	respondedA := make(chan struct{}, 1)
+	respondedB := make(chan struct{}, 1)
+	respondWithPrompt(sessionA, "prompt-1", respondedA)
+	respondWithPrompt(sessionB, "prompt-1", respondedB)
+	sessionA.SendStart(deviceA)
+	sessionB.SendStart(deviceB)
+	for _, responded := range []chan struct{}{respondedA, respondedB} {
+		select {
+		case <-responded:
+		case <-time.After(5 * time.Second):
+			t.Fatal("the workflow did not say")
+		}
+	}
+	notYet := func(why string) {
+		t.Helper()
+		select {
+		case sourceUri := <-said:
+			t.Fatalf("SayAndWait of %s returned %s", sourceUri, why)
+		case <-time.After(50 * time.Millisecond):
+		}
+	}
+	saidBy := func(want string) {
+		t.Helper()
+		select {
+		case sourceUri := <-said:
+			if sourceUri != want {
+				t.Fatalf("SayAndWait of %s returned, want %s", sourceUri, want)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatalf("SayAndWait of %s did not return", want)
+		}
+	}
+
+	// another prompt of B stops, and B's prompt starts
+	sendPrompt(sessionB, "stopped", "prompt-2")
+	sendPrompt(sessionB, "started", "prompt-1")
+	notYet("on the stopped event of another prompt")
+
+	// A's prompt stops before it started, B's prompt is still playing
+	sendPrompt(sessionA, "stopped", "prompt-1")
+	sendPrompt(sessionA, "started", "prompt-1")
+	saidBy(deviceA)
+	notYet("on the stopped event of another instance")
+
+	sendPrompt(sessionB, "stopped", "prompt-1")
+	saidBy(deviceB)
+}
+
+// Two SayAndWait of one instance whose prompts stop in the reverse order each return on the
+// stopped event of their own prompt.
+func TestConcurrentSayAndWait(t *testing.T) {
+	server := relaytest.NewServer()
+	defer server.Close()
+	said := make(chan string, 2)
+	server.AddWorkflow("say", func(api sdk.RelayApi) {
+		api.OnStart(func(startEvent sdk.StartEvent) {
+			sourceUri := api.GetSourceUri(startEvent)
+			first := api.Async().SayAndWait(api.Context(), sourceUri, "first", sdk.ENGLISH)
+			second := api.Async().SayAndWait(api.Context(), sourceUri, "second", sdk.ENGLISH)
+			for _, future := range []*sdk.Future[sdk.SayResponse]{first, second} {
+				go func(future *sdk.Future[sdk.SayResponse]) {
+					res, err := future.Wait(context.Background())
+					if err != nil {
+						t.Error(err)
+					}
+					said <- res.CorrelationId
+				}(future)
+			}
+		})
+	})
+	session, err := server.Dial("say")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer session.Close()
+	responded := make(chan string, 2)
+	session.Respond("say", func(req relaytest.Request) []map[string]interface{} {
+		promptId := "prompt-" + req.String("text")
+		session.Send(map[string]interface{}{"_type": "wf_api_say_response", "_id": req.Id, "id": promptId})
+		responded <- promptId
+		return nil
+	})
+	session.SendStart(deviceA)
+	for i := 0; i < 2; i++ {
+		select {
+		case <-responded:
+		case <-time.After(5 * time.Second):
+			t.Fatal("the workflow did not say")
+		}
+	}
+
+	for _, promptId := range []string{"prompt-second", "prompt-first"} {
+		sendPrompt(session, "started", promptId)
+	}
+	for _, promptId := range []string{"prompt-second", "prompt-first"} {
+		sendPrompt(session, "stopped", promptId)
+		select {
+		case got := <-said:
+			if got != promptId {
+				t.Fatalf("the SayAndWait of %s returned, want %s", got, promptId)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatalf("the SayAndWait of %s did not return", promptId)
+		}
+	}
+}
@@ -0,0 +1,284 @@
+// Copyright © 2022 Relay Inc.
+
+package sdk
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http/httptest"
+	"os"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	log "github.com/sirupsen/logrus"
+)
+
+// the parts of protocol.json the tests check the generated code against
+type testSchema struct {
+	Events   []testMessage `json:"events"`
+	Requests []struct {
+		testMessage
+		Target   bool         `json:"target"`
+		Response *testMessage `json:"response"`
+		Methods  []struct {
+			Name string `json:"name"`
+		} `json:"methods"`
+	} `json:"requests"`
+}
+
+type testMessage struct {
+	Name   string `json:"name"`
+	Type   string `json:"type"`
+	Raw    bool   `json:"raw"`
+	Fields []struct {
+		Name string `json:"name"`
+		Json string `json:"json"`
+	} `json:"fields"`
+}
+
+// the json names of the message's fields, by the _type of each message in the schema
+func readSchema(t *testing.T) (*testSchema, map[string][]string, map[string]bool) {
+	t.Helper()
+	data, err := os.ReadFile("protocol.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	schema := &testSchema{}
+	if err := json.Unmarshal(data, schema); err != nil {
+		t.Fatal(err)
+	}
+	names := make(map[string][]string)
+	raw := make(map[string]bool)
+	add := func(wireType string, msg testMessage, extra ...string) {
+		for _, f := range msg.Fields {
+			if f.Json != "" {
+				extra = append(extra, f.Json)
+			} else {
+				extra = append(extra, f.Name)
+			}
+		}
+		sort.Strings(extra)
+		names[wireType] = append([]string{}, extra...)
+		raw[wireType] = msg.Raw
+	}
+	for _, event := range schema.Events {
+		add("wf_api_"+event.Name+"_event", event)
+	}
+	for _, req := range schema.Requests {
+		if req.Target {
+			add("wf_api_"+req.Name+"_request", req.testMessage, "_type", "_id", "_target")
+		} else {
+			add("wf_api_"+req.Name+"_request", req.testMessage, "_type", "_id")
+		}
+		if req.Response != nil {
+			add("wf_api_"+req.Name+"_response", *req.Response)
+		}
+	}
+	return schema, names, raw
+}
+
+// Every generated message, with all of its fields set, must be encoded with the json names of
+// the schema, and decode to the same value.
+func TestProtocolRoundTrip(t *testing.T) {
+	_, names, raw := readSchema(t)
+	if len(protocolTypes) != len(names) {
+		t.Errorf("%d generated types for %d messages in the schema, run go generate ./pkg/sdk", len(protocolTypes), len(names))
+	}
+	for wireType, want := range names {
+		value, ok := protocolTypes[wireType]
+		if !ok {
+			t.Errorf("%s: no generated type, run go generate ./pkg/sdk", wireType)
+			continue
+		}
+		msg := reflect.New(reflect.TypeOf(value))
+		n := 0
+		fillValue(msg.Elem(), &n)
+		data, err := json.Marshal(msg.Interface())
+		if err != nil {
+			t.Errorf("%s: %v", wireType, err)
+			continue
+		}
+		var fields map[string]interface{}
+		if err := json.Unmarshal(data, &fields); err != nil {
+			t.Errorf("%s: %v", wireType, err)
+			continue
+		}
+		got := make([]string, 0, len(fields))
+		for name := range fields {
+			got = append(got, name)
+		}
+		sort.Strings(got)
+		if raw[wireType] {
+			// all the fields of the map, whatever they are
+			want = got
+			if len(got) == 0 {
+				t.Errorf("%s: the fields of the raw message were not encoded", wireType)
+			}
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("%s: encoded with %v, the schema has %v", wireType, got, want)
+		}
+		decoded := reflect.New(reflect.TypeOf(value))
+		if err := json.Unmarshal(data, decoded.Interface()); err != nil {
+			t.Errorf("%s: %v", wireType, err)
+		} else if !reflect.DeepEqual(decoded.Elem().Interface(), msg.Elem().Interface()) {
+			t.Errorf("%s: %s decoded to %+v", wireType, data, decoded.Elem().Interface())
+		}
+	}
+}
+
+// The api methods generated from the schema must be declared in RelayApi as well.
+func TestProtocolMethodsInApi(t *testing.T) {
+	schema, _, _ := readSchema(t)
+	api := reflect.TypeOf((*RelayApi)(nil)).Elem()
+	impl := reflect.TypeOf(&workflowInstance{})
+	for _, req := range schema.Requests {
+		for _, m := range req.Methods {
+			for _, name := range []string{m.Name, m.Name + "Ctx"} {
+				apiMethod, ok := api.MethodByName(name)
+				if !ok {
+					t.Errorf("%s is generated but not declared in RelayApi", name)
+					continue
+				}
+				if implMethod, ok := impl.MethodByName(name); !ok || implMethod.Type.NumIn() != apiMethod.Type.NumIn()+1 {
+					t.Errorf("%s of RelayApi does not match the generated method", name)
+				}
+			}
+		}
+	}
+}
+
+// Each request is sent by its generated method with the json names of the schema, and the
+// method returns the response, or the reply, the request is answered with.
+func TestProtocolRequests(t *testing.T) {
+	schema, names, _ := readSchema(t)
+	if len(protocolRequests) != len(schema.Requests) {
+		t.Errorf("%d generated send methods for %d requests in the schema, run go generate ./pkg/sdk", len(protocolRequests), len(schema.Requests))
+	}
+	logger := log.New()
+	logger.SetOutput(io.Discard)
+	server := NewServer(WithLogger(logger))
+	instances := make(chan *workflowInstance, 1)
+	server.AddWorkflow("requests", func(api RelayApi) {
+		instances <- api.(*workflowInstance)
+	})
+	httpServer := httptest.NewServer(server)
+	defer httpServer.Close()
+	conn, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(httpServer.URL, "http")+"/requests", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	wfInst := <-instances
+	defer wfInst.Cancel()
+
+	wireTypes := make([]string, 0, len(protocolRequests))
+	for wireType := range protocolRequests {
+		wireTypes = append(wireTypes, wireType)
+	}
+	sort.Strings(wireTypes)
+	contextType := reflect.TypeOf((*context.Context)(nil)).Elem()
+	for _, wireType := range wireTypes {
+		method := reflect.ValueOf(protocolRequests[wireType])
+		args := []reflect.Value{reflect.ValueOf(wfInst)}
+		n := 0
+		for i := 1; i < method.Type().NumIn(); i++ {
+			arg := reflect.New(method.Type().In(i)).Elem()
+			if arg.Type() == contextType {
+				arg.Set(reflect.ValueOf(context.Background()))
+			} else {
+				fillValue(arg, &n)
+			}
+			args = append(args, arg)
+		}
+		results := make(chan []reflect.Value, 1)
+		go func() {
+			results <- method.Call(args)
+		}()
+
+		var req map[string]interface{}
+		conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+		if err := conn.ReadJSON(&req); err != nil {
+			t.Fatalf("%s: %v", wireType, err)
+		}
+		got := make([]string, 0, len(req))
+		for name := range req {
+			got = append(got, name)
+		}
+		sort.Strings(got)
+		if req["_type"] != wireType || !reflect.DeepEqual(got, names[wireType]) {
+			t.Errorf("%s: sent a %v with %v, the schema has %v", wireType, req["_type"], got, names[wireType])
+		}
+		if method.Type().NumOut() == 0 {
+			// not answered
+			continue
+		}
+
+		// a reply that is not the response is an event, which carries the id of the request
+		// in request_id
+		replyType := reflect.TypeOf(protocolTypes[strings.TrimSuffix(wireType, "_request")+"_response"])
+		reply := map[string]interface{}{"_type": strings.TrimSuffix(wireType, "_request") + "_response", "_id": req["_id"]}
+		if method.Type().Out(0) != replyType {
+			for eventType, value := range protocolTypes {
+				if reflect.TypeOf(value) == method.Type().Out(0) {
+					reply = map[string]interface{}{"_type": eventType, "request_id": req["_id"]}
+				}
+			}
+		}
+		if err := conn.WriteJSON(reply); err != nil {
+			t.Fatal(err)
+		}
+		select {
+		case res := <-results:
+			if err, _ := res[1].Interface().(error); err != nil {
+				t.Errorf("%s: %v", wireType, err)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatalf("%s: the method did not return on the reply %v", wireType, reply)
+		}
+	}
+}
+
+// sets every field of v to a distinct value that is not the zero value
+func fillValue(v reflect.Value, n *int) {
+	*n++
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString("value-" + strconv.Itoa(*n))
+	case reflect.Bool:
+		v.SetBool(true)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		v.SetInt(int64(*n))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		v.SetUint(uint64(*n))
+	case reflect.Float32, reflect.Float64:
+		v.SetFloat(float64(*n) + 0.5)
+	case reflect.Interface:
+		v.Set(reflect.ValueOf("value-" + strconv.Itoa(*n)))
+	case reflect.Ptr:
+		v.Set(reflect.New(v.Type().Elem()))
+		fillValue(v.Elem(), n)
+	case reflect.Slice:
+		v.Set(reflect.MakeSlice(v.Type(), 1, 1))
+		fillValue(v.Index(0), n)
+	case reflect.Map:
+		key := reflect.New(v.Type().Key()).Elem()
+		fillValue(key, n)
+		elem := reflect.New(v.Type().Elem()).Elem()
+		fillValue(elem, n)
+		v.Set(reflect.MakeMap(v.Type()))
+		v.SetMapIndex(key, elem)
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			if v.Field(i).CanSet() {
+				fillValue(v.Field(i), n)
+			}
+		}
+	}
+}
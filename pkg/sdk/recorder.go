@@ -0,0 +1,109 @@
+// Copyright © 2022 Relay Inc.
+
+package sdk
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// The direction of a recorded frame, seen from the workflow.
+const (
+	RECORD_INBOUND  = "in"  // sent by the Relay server
+	RECORD_OUTBOUND = "out" // sent by the workflow
+)
+
+// A frame in a recording, one per line of the JSONL file written by WithRecorder. Frame is
+// the frame as it went over the websocket, or a JSON string if it was not valid JSON.
+type RecordedFrame struct {
+	Time      time.Time       `json:"time"`
+	Direction string          `json:"dir"`
+	Frame     json.RawMessage `json:"frame"`
+}
+
+// An IdGenerator makes the ids of the requests of a workflow instance. It must be safe for
+// concurrent use.
+type IdGenerator func() string
+
+// Returns an IdGenerator making the ids "1", "2", "3" and so on, so the ids a workflow
+// instance uses are the same in each run, i.e. when replaying a recording. Pass it to
+// WithIdGenerator.
+func SequentialIds() IdGenerator {
+	var next int64
+	return func() string {
+		return strconv.FormatInt(atomic.AddInt64(&next, 1), 10)
+	}
+}
+
+// Records every frame a workflow instance sends and receives, with a timestamp, to the
+// writer open returns for the instance, see RecordedFrame. The writer is closed when the
+// instance ends. If open fails, the instance runs without a recording.
+func WithRecorder(open func(workflowName string) (io.WriteCloser, error)) ServerOption {
+	return func(server *Server) {
+		server.openRecording = open
+	}
+}
+
+// Same as WithRecorder, but writes each instance's frames to a new file in dir, named after
+// the workflow and the time the instance started.
+func WithRecordingDir(dir string) ServerOption {
+	return WithRecorder(func(workflowName string) (io.WriteCloser, error) {
+		name := fmt.Sprintf("%s-%s.jsonl", workflowName, time.Now().UTC().Format("20060102T150405.000000000"))
+		return os.Create(filepath.Join(dir, name))
+	})
+}
+
+// Sets the id generator of the requests; newIdGenerator is called once per workflow
+// instance, i.e. SequentialIds. By default ids are random.
+func WithIdGenerator(newIdGenerator func() IdGenerator) ServerOption {
+	return func(server *Server) {
+		server.newIdGenerator = newIdGenerator
+	}
+}
+
+// Writes the frames of a workflow instance. A nil recorder records nothing.
+type recorder struct {
+	mutex   sync.Mutex
+	out     io.WriteCloser
+	encoder *json.Encoder
+}
+
+func newRecorder(out io.WriteCloser) *recorder {
+	return &recorder{out: out, encoder: json.NewEncoder(out)}
+}
+
+func (rec *recorder) record(direction string, frame []byte) {
+	if rec == nil {
+		return
+	}
+	recorded := RecordedFrame{Time: time.Now().UTC(), Direction: direction, Frame: frame}
+	if !json.Valid(frame) {
+		recorded.Frame, _ = json.Marshal(string(frame))
+	}
+	rec.mutex.Lock()
+	defer rec.mutex.Unlock()
+	if rec.out != nil {
+		rec.encoder.Encode(recorded)
+	}
+}
+
+func (rec *recorder) close() error {
+	if rec == nil {
+		return nil
+	}
+	rec.mutex.Lock()
+	defer rec.mutex.Unlock()
+	out := rec.out
+	rec.out = nil
+	if out == nil {
+		return nil
+	}
+	return out.Close()
+}
@@ -0,0 +1,71 @@
+// Copyright © 2022 Relay Inc.
+
+package sdk_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"relay-go/pkg/relaytest"
+	"relay-go/pkg/sdk"
+)
+
+// A Ctx request fails with ErrServerError when the Relay server answers with an error
+// response, and with a decoding error when the response does not decode, instead of
+// returning a zero value.
+func TestCtxRequestErrors(t *testing.T) {
+	server := relaytest.NewServer()
+	defer server.Close()
+	results := make(chan error, 2)
+	server.AddWorkflow("errors", func(api sdk.RelayApi) {
+		api.OnStart(func(startEvent sdk.StartEvent) {
+			ctx, cancel := context.WithTimeout(api.Context(), 5*time.Second)
+			defer cancel()
+			_, err := api.GetVarCtx(ctx, "rejected", "")
+			results <- err
+			_, err = api.GetVarCtx(ctx, "garbled", "")
+			results <- err
+		})
+	})
+	session, err := server.Dial("errors")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer session.Close()
+	session.Respond("get_var", func(req relaytest.Request) []map[string]interface{} {
+		if req.String("name") == "rejected" {
+			return []map[string]interface{}{{
+				"_type":        "wf_api_error_response",
+				"_id":          req.Id,
+				"code":         "invalid_name",
+				"message":      "no variable named rejected",
+				"request_type": "wf_api_get_var_request",
+			}}
+		}
+		// the value has the wrong type
+		return []map[string]interface{}{{"_type": "wf_api_get_var_response", "_id": req.Id, "value": 5}}
+	})
+	session.SendStart(deviceA)
+
+	for _, check := range []func(err error){
+		func(err error) {
+			if !errors.Is(err, sdk.ErrServerError) {
+				t.Errorf("the rejected request failed with %v, want ErrServerError", err)
+			}
+		},
+		func(err error) {
+			if err == nil || errors.Is(err, sdk.ErrServerError) {
+				t.Errorf("the garbled response gave %v, want a decoding error", err)
+			}
+		},
+	} {
+		select {
+		case err := <-results:
+			check(err)
+		case <-time.After(5 * time.Second):
+			t.Fatal("the request did not complete")
+		}
+	}
+}
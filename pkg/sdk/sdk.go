@@ -3,52 +3,378 @@
 package sdk
 
 import (
+    "context"
+    "crypto/tls"
+    "io"
+    "net"
     "net/http"
     "encoding/json"
+    "errors"
     "regexp"
+    "sync"
+    "time"
     log "github.com/sirupsen/logrus"
     "github.com/gorilla/mux"
     "github.com/gorilla/websocket"
 )
 
-var upgrader = websocket.Upgrader{
-    ReadBufferSize:  1024,
-    WriteBufferSize: 1024,
+// Returned by ListenAndServe and Serve when the server is already serving.
+var ErrServerStarted = errors.New("relay sdk server already started")
+
+// The stop reason given to workflow instances that are still running when the
+// deadline passed to Shutdown expires.
+const SHUTDOWN_STOP_REASON = "server_shutdown"
+
+// How long Shutdown waits for the instances it stopped to handle the STOP event and finish,
+// once its deadline expired.
+const SHUTDOWN_STOP_TIMEOUT = 5 * time.Second
+
+// A Server accepts websocket connections from the Relay server and runs the workflow
+// registered under the requested path.  Each Server keeps its own set of workflows, so
+// several servers can coexist in one process.  A Server is also an http.Handler, and can
+// be mounted in an existing HTTP service instead of calling ListenAndServe.
+type Server struct {
+    addr       string
+    router     *mux.Router
+    pathPrefix string
+    upgrader   websocket.Upgrader
+    logger     log.FieldLogger
+    tlsConfig  *tls.Config
+    certFile   string
+    keyFile    string
+
+    eventQueueCapacity int
+    overflowPolicy     OverflowPolicy
+    droppableEvents    []Event
+    eventQueueMetrics  func(stats EventQueueStats)
+    handlerWorkers     int
+    handlerOrdering    HandlerOrdering
+    panicHandler       func(info PanicInfo)
+    keepalive          keepalive
+    openRecording      func(workflowName string) (io.WriteCloser, error)
+    newIdGenerator     func() IdGenerator
+
+    mutex        sync.Mutex
+    workflows    map[string]func(api RelayApi)
+    httpServer   *http.Server
+    instances    map[*workflowInstance]struct{}
+    shuttingDown bool
 }
 
-// this should return an interface that has a workflow() function that they can pass their workflow implementations to
-func InitializeRelaySdk(port string) {
-    log.Info("starting http server on ", port)
-    
-    // use gorilla mux router
-    r := mux.NewRouter()
-    
-    r.HandleFunc("/{workflowname}", handleWs)
-    
-    http.ListenAndServe(port, r)
+// A ServerOption configures a Server created with NewServer.
+type ServerOption func(server *Server)
+
+// Sets the TCP address the server listens on in ListenAndServe, i.e. ":8080".
+func WithAddr(addr string) ServerOption {
+    return func(server *Server) {
+        server.addr = addr
+    }
 }
 
-func AddWorkflow(workflowName string, fn func(api RelayApi)) {
+// Registers the workflow route on an existing gorilla mux router instead of a new one.
+func WithRouter(router *mux.Router) ServerOption {
+    return func(server *Server) {
+        server.router = router
+    }
+}
+
+// Serves the workflows below a path prefix, i.e. "/relay" serves the workflow "hello"
+// on "/relay/hello".
+func WithPathPrefix(prefix string) ServerOption {
+    return func(server *Server) {
+        server.pathPrefix = prefix
+    }
+}
+
+// Sets the read and write buffer sizes, in bytes, of the websocket upgrader.
+func WithBufferSizes(readBufferSize int, writeBufferSize int) ServerOption {
+    return func(server *Server) {
+        server.upgrader.ReadBufferSize = readBufferSize
+        server.upgrader.WriteBufferSize = writeBufferSize
+    }
+}
+
+// Sets the logger used by the server and the workflow instances it runs.  Defaults to
+// the standard logrus logger.
+func WithLogger(logger log.FieldLogger) ServerOption {
+    return func(server *Server) {
+        server.logger = logger
+    }
+}
+
+// Sets the capacity of each workflow instance's event queue and what happens to events that
+// arrive while it is full, see OverflowPolicy.  droppable lists the event types that may be
+// dropped with OVERFLOW_DROP_BY_TYPE.  By default the queue is unbounded.
+func WithEventQueue(capacity int, policy OverflowPolicy, droppable ...Event) ServerOption {
+    return func(server *Server) {
+        server.eventQueueCapacity = capacity
+        server.overflowPolicy = policy
+        server.droppableEvents = droppable
+    }
+}
+
+// Registers a function that is called with the stats of a workflow instance's event queue
+// each time an event is queued or dropped, i.e. to export the queue depth as a metric.  It
+// is called on the goroutine reading the websocket, and must not block.
+func WithEventQueueMetrics(fn func(stats EventQueueStats)) ServerOption {
+    return func(server *Server) {
+        server.eventQueueMetrics = fn
+    }
+}
+
+// Runs the event handlers of each workflow instance concurrently, at most workers at a time,
+// instead of one after the other.  Events from the same source are still handled in the
+// order they arrived, see HandlerOrdering.  START and STOP events are handled on their own,
+// after all earlier events.  Handlers must then synchronize access to shared state.
+func WithConcurrentHandlers(workers int, ordering HandlerOrdering) ServerOption {
+    return func(server *Server) {
+        server.handlerWorkers = workers
+        server.handlerOrdering = ordering
+    }
+}
+
+// Registers a function that is called when a workflow instance recovers from a panic, i.e.
+// to report it to an error tracker.  The panic is logged either way, and the instance is
+// stopped with the reason PANIC_STOP_REASON, while the other instances keep running.
+func WithPanicHandler(fn func(info PanicInfo)) ServerOption {
+    return func(server *Server) {
+        server.panicHandler = fn
+    }
+}
+
+// Sets how often each workflow instance pings the Relay server, and how much longer it waits
+// for the pong before it considers the connection dead, closes it and calls the OnDisconnect
+// handler.  Defaults to DEFAULT_PING_INTERVAL and DEFAULT_PONG_TIMEOUT.  A pingInterval of 0
+// disables the pings.
+func WithKeepalive(pingInterval time.Duration, pongTimeout time.Duration) ServerOption {
+    return func(server *Server) {
+        server.keepalive.pingInterval = pingInterval
+        server.keepalive.pongTimeout = pongTimeout
+    }
+}
+
+// Sets the read and write deadlines of the websockets.  A connection is closed when nothing,
+// not even a pong, was received for readTimeout; with 0 this is the ping interval plus the
+// pong timeout, or no limit if pings are disabled.  A write that takes longer than
+// writeTimeout fails the request, and closes the connection, which calls the OnDisconnect
+// handler.  writeTimeout defaults to DEFAULT_WRITE_TIMEOUT, 0 disables it.
+func WithDeadlines(readTimeout time.Duration, writeTimeout time.Duration) ServerOption {
+    return func(server *Server) {
+        server.keepalive.readTimeout = readTimeout
+        server.keepalive.writeTimeout = writeTimeout
+    }
+}
+
+// Creates a Server configured with the given options.  Workflows are added with AddWorkflow.
+func NewServer(opts ...ServerOption) *Server {
+    server := &Server{
+        addr: ":8080",
+        upgrader: websocket.Upgrader{
+            ReadBufferSize:  1024,
+            WriteBufferSize: 1024,
+        },
+        logger: log.StandardLogger(),
+        workflows: make(map[string]func(api RelayApi)),
+        instances: make(map[*workflowInstance]struct{}),
+        keepalive: keepalive{
+            pingInterval: DEFAULT_PING_INTERVAL,
+            pongTimeout: DEFAULT_PONG_TIMEOUT,
+            writeTimeout: DEFAULT_WRITE_TIMEOUT,
+        },
+    }
+    for _, opt := range opts {
+        opt(server)
+    }
+    if server.router == nil {
+        server.router = mux.NewRouter()
+    }
+
+    router := server.router
+    if server.pathPrefix != "" {
+        router = router.PathPrefix(server.pathPrefix).Subrouter()
+    }
+    router.HandleFunc("/{workflowname}", server.handleWs)
+    return server
+}
+
+// Registers a workflow under a URL path name.  When the Relay server connects to that
+// path, a new workflow instance is started and fn is called with its RelayApi.
+func (server *Server) AddWorkflow(workflowName string, fn func(api RelayApi)) {
     // here we just register the wf by name, when a ws connects it will call the ws function passing the websocket in
-    workflowMap[workflowName] = fn
-    log.Info("Added workflow named ", workflowName, " map is ", workflowMap)
+    server.mutex.Lock()
+    server.workflows[workflowName] = fn
+    server.mutex.Unlock()
+    server.logger.Info("Added workflow named ", workflowName)
+}
+
+// Implements http.Handler, so the server can be mounted in an existing HTTP service.
+func (server *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+    server.router.ServeHTTP(w, r)
+}
+
+// Listens on the configured address and serves the workflows, over TLS if it was
+// configured with WithTLS or WithTLSConfig.  Blocks until the server fails, and always
+// returns a non-nil error.
+func (server *Server) ListenAndServe() error {
+    server.mutex.Lock()
+    addr := server.addr
+    server.mutex.Unlock()
+    listener, err := net.Listen("tcp", addr)
+    if err != nil {
+        return err
+    }
+    return server.Serve(listener)
 }
 
-func handleWs(w http.ResponseWriter, r *http.Request) {
+// Serves the workflows on connections accepted from listener, over TLS if it was
+// configured with WithTLS or WithTLSConfig.  Blocks until the server fails, and always
+// returns a non-nil error.  After Shutdown, it closes listener and returns
+// http.ErrServerClosed.
+func (server *Server) Serve(listener net.Listener) error {
+    tlsConfig, err := server.serverTLSConfig()
+    if err != nil {
+        listener.Close()
+        return err
+    }
+    if tlsConfig != nil {
+        listener = tls.NewListener(listener, tlsConfig)
+    }
+
+    server.mutex.Lock()
+    if server.shuttingDown {
+        server.mutex.Unlock()
+        listener.Close()
+        return http.ErrServerClosed
+    }
+    if server.httpServer != nil {
+        server.mutex.Unlock()
+        listener.Close()
+        return ErrServerStarted
+    }
+    httpServer := &http.Server{Handler: server.router}
+    server.httpServer = httpServer
+    server.mutex.Unlock()
+
+    server.logger.Info("starting http server on ", listener.Addr())
+    return httpServer.Serve(listener)
+}
+
+// Gracefully shuts down the server.  New websocket connections are refused, and Shutdown
+// waits for the running workflow instances to finish.  If ctx expires first, the
+// remaining instances are sent a STOP event with the reason SHUTDOWN_STOP_REASON and
+// their websockets are closed, Shutdown waits up to SHUTDOWN_STOP_TIMEOUT for them to
+// finish, and the context's error is returned.
+func (server *Server) Shutdown(ctx context.Context) error {
+    server.mutex.Lock()
+    server.shuttingDown = true
+    httpServer := server.httpServer
+    server.mutex.Unlock()
+
+    var err error
+    if httpServer != nil {
+        // websocket connections are hijacked, so this only stops the listener and idle connections
+        err = httpServer.Shutdown(ctx)
+    }
+
+    for _, wfInst := range server.runningInstances() {
+        select {
+            case <-wfInst.Done:
+            case <-ctx.Done():
+                server.stopInstances()
+                return ctx.Err()
+        }
+    }
+    return err
+}
+
+// stops the running workflow instances and waits, for up to SHUTDOWN_STOP_TIMEOUT, until
+// they finished
+func (server *Server) stopInstances() {
+    instances := server.runningInstances()
+    for _, wfInst := range instances {
+        wfInst.stop(SHUTDOWN_STOP_REASON)
+    }
+    timeout := time.NewTimer(SHUTDOWN_STOP_TIMEOUT)
+    defer timeout.Stop()
+    for _, wfInst := range instances {
+        select {
+            case <-wfInst.Done:
+            case <-timeout.C:
+                server.logger.Warn("workflow instances did not stop within ", SHUTDOWN_STOP_TIMEOUT)
+                return
+        }
+    }
+}
+
+// Returns the stats of the event queues of the running workflow instances.
+func (server *Server) EventQueueStats() []EventQueueStats {
+    instances := server.runningInstances()
+    stats := make([]EventQueueStats, 0, len(instances))
+    for _, wfInst := range instances {
+        stats = append(stats, wfInst.EventQueue.stats())
+    }
+    return stats
+}
+
+func (server *Server) runningInstances() []*workflowInstance {
+    server.mutex.Lock()
+    defer server.mutex.Unlock()
+    instances := make([]*workflowInstance, 0, len(server.instances))
+    for wfInst := range server.instances {
+        instances = append(instances, wfInst)
+    }
+    return instances
+}
+
+// the server used by the package level InitializeRelaySdk and AddWorkflow functions
+var defaultServer = NewServer()
+
+// Starts the default server on the given address, i.e. ":8080", and serves the workflows
+// registered with AddWorkflow.  Blocks until the server fails, and returns the error.
+// Use NewServer for more control over the server.
+func InitializeRelaySdk(port string) error {
+    defaultServer.mutex.Lock()
+    defaultServer.addr = port
+    defaultServer.mutex.Unlock()
+    err := defaultServer.ListenAndServe()
+    defaultServer.logger.Error("http server stopped: ", err)
+    return err
+}
+
+// Registers a workflow on the default server used by InitializeRelaySdk.
+func AddWorkflow(workflowName string, fn func(api RelayApi)) {
+    defaultServer.AddWorkflow(workflowName, fn)
+}
+
+// Gracefully shuts down the default server started by InitializeRelaySdk. See Server.Shutdown.
+func Shutdown(ctx context.Context) error {
+    return defaultServer.Shutdown(ctx)
+}
+
+func (server *Server) handleWs(w http.ResponseWriter, r *http.Request) {
     vars := mux.Vars(r)
     wfName := vars["workflowname"]
-    log.Debug("workflow name requested: ", wfName)
+    server.logger.Debug("workflow name requested: ", wfName)
     
-    wfFunc, ok := workflowMap[wfName]
+    server.mutex.Lock()
+    wfFunc, ok := server.workflows[wfName]
+    shuttingDown := server.shuttingDown
+    server.mutex.Unlock()
+    if shuttingDown {
+        server.logger.Debug("refusing workflow ", wfName, ", server is shutting down")
+        http.Error(w, "server is shutting down", http.StatusServiceUnavailable)
+        return
+    }
     if !ok {
-        log.Debug("no workflow named ", wfName, " is registered ", workflowMap)
+        server.logger.Debug("no workflow named ", wfName, " is registered")
+        http.NotFound(w, r)
         return
     }
         
-    conn, upgradeErr := upgrader.Upgrade(w, r, nil)
+    conn, upgradeErr := server.upgrader.Upgrade(w, r, nil)
 
     if upgradeErr != nil {
-        log.Debug("upgrade error ", upgradeErr)
+        server.logger.Debug("upgrade error ", upgradeErr)
         return
     }
     
@@ -56,20 +382,73 @@ func handleWs(w http.ResponseWriter, r *http.Request) {
     // run the wf func by passing the ws connection to it
     // the name of the workflow is in the path that was requested
     
-    // need to pass the ws conn, and the relayapi interface? do we really need anything except the conn?
-    
     // start an async function to run the wf and handle the ws 
+    ctx, cancel := context.WithCancel(context.Background())
     wfInst := &workflowInstance{
         WebsocketConnection: conn, 
+        WorkflowName: wfName,
         WorkflowFn: wfFunc, 
+        Logger: server.logger.WithField("workflow", wfName),
+        Ctx: ctx,
+        Cancel: cancel,
         Pending: make(map[string]*Call), 
-        EventChannel: make(chan EventWrapper, 100),
+        Prompts: make(map[string]chan struct{}),
+        EventQueue: newEventQueue(wfName, server.eventQueueCapacity, server.overflowPolicy, server.droppableEvents),
+        Disconnected: make(chan struct{}),
+        Done: make(chan struct{}),
+        PanicHandler: server.panicHandler,
+        Keepalive: server.keepalive,
+        Outbox: make(chan outboundFrame, 64),
     }
-    go startWorkflow(wfInst, wfName)
-    
+    if server.newIdGenerator != nil {
+        wfInst.IdGenerator = server.newIdGenerator()
+    }
+    if server.openRecording != nil {
+        if out, err := server.openRecording(wfName); err != nil {
+            wfInst.Logger.Error("not recording the workflow instance: ", err)
+        } else {
+            wfInst.Recorder = newRecorder(out)
+        }
+    }
+    wfInst.EventQueue.canBlock = wfInst.mayBlockEvents
+    wfInst.EventQueue.divert = wfInst.divertToWaiter
+    wfInst.EventQueue.onStats = server.eventQueueMetrics
+    wfInst.EventQueue.onBlock = wfInst.readBlocked
+    if server.handlerWorkers > 0 {
+        wfInst.Dispatcher = newDispatcher(wfInst, server.handlerWorkers, server.handlerOrdering)
+    }
+
+    // register the instance under the same lock as the check, so that Shutdown either sees it
+    // or the connection is refused, the server may have started shutting down during the upgrade
+    server.mutex.Lock()
+    if server.shuttingDown {
+        server.mutex.Unlock()
+        server.logger.Debug("refusing workflow ", wfName, ", server is shutting down")
+        cancel()
+        wfInst.Recorder.close()
+        conn.WriteControl(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseGoingAway, "server is shutting down"), time.Now().Add(time.Second))
+        conn.Close()
+        return
+    }
+    server.instances[wfInst] = struct{}{}
+    server.mutex.Unlock()
+
+    go wfInst.writeFrames()
+    go func() {
+        startWorkflow(wfInst)
+        if err := wfInst.Recorder.close(); err != nil {
+            wfInst.Logger.Error("error closing the recording: ", err)
+        }
+        server.mutex.Lock()
+        delete(server.instances, wfInst)
+        server.mutex.Unlock()
+    }()
 }
 
-func startWorkflow(wfInst *workflowInstance, workflowName string) {
+func startWorkflow(wfInst *workflowInstance) {
+    defer close(wfInst.Done)
+    defer wfInst.EventQueue.close()
+
     // this thread blocks in 2 places, when waiting for a message to come over the ws, or when waiting for a response to 
     // a request that was sent. ws listening in done on a separate coroutine, event messages are sent to this coroutine,
     // and response messages are handled on the listening corouting to complete the call object since this coroutine will
@@ -77,43 +456,72 @@ func startWorkflow(wfInst *workflowInstance, workflowName string) {
     
     // this looks weird, but the wfInst struct holds the user's workflow function, and we pass the wfInst to it because it implements the RelayApi interface that the workflowFn accepts
     // call the user defined wf function, passing the RelayApi interface to it (which is implemented on the workflowInstance struct)
-    wfInst.WorkflowFn(wfInst)
+    wfInst.runWorkflowFn()
     
     // listen for ws messages in a coroutine so we can receive responses while blocking on this coroutine
     go wfInst.receiveWs()
 
-    log.Info("Workflow instance started for ", workflowName)
+    wfInst.Logger.Info("Workflow instance started")
 
-    // loop forever handling events and responses    
+    // loop handling events until the websocket is gone
     var err error 
     for err == nil {
+        if eventWrapper, ok := wfInst.EventQueue.pop(); ok {
+            err = wfInst.dispatchEvent(eventWrapper)
+            continue
+        }
         select {
-            case eventWrapper := <-wfInst.EventChannel:
-                err = wfInst.handleEvent(eventWrapper)
+            case <-wfInst.EventQueue.ready:
+            case <-wfInst.Disconnected:
+                err = wfInst.drainEvents()
+                if disconnectErr := wfInst.disconnectErr(); disconnectErr != nil {
+                    wfInst.disconnected(disconnectErr)
+                }
+                if err == nil {
+                    err = errors.New("websocket closed, stop reason: " + wfInst.stopReason())
+                }
         }
     }
-    log.Debug("exiting, err is ", err)
-    log.Info("Workflow instance terminating, reason: ", err)
+    if wfInst.Dispatcher != nil {
+        wfInst.Dispatcher.wait()
+    }
+    wfInst.Logger.Debug("exiting, err is ", err)
+    wfInst.Logger.Info("Workflow instance terminating, reason: ", err)
+}
+
+func (wfInst *workflowInstance) runWorkflowFn() {
+    defer wfInst.recoverPanic()
+    wfInst.WorkflowFn(wfInst)
 }
 
 var eventRegex = regexp.MustCompile(`^wf_api_(.+)_event$`)
 var responseRegex = regexp.MustCompile(`^wf_api_(.+)_response$`)
 
-func parseMessage(msg []byte) (map[string]interface{}, Event, string) {
+// decodes a frame from the Relay server, and tells its event name and whether it is an event
+// or a response. Returns a *FrameError if the frame is not a valid event or response.
+func parseMessage(msg []byte) (map[string]interface{}, Event, string, error) {
     var parsedMsg map[string]interface{}
-    json.Unmarshal(msg, &parsedMsg)
+    if err := json.Unmarshal(msg, &parsedMsg); err != nil {
+        return nil, "", "", &FrameError{Reason: "invalid json", Frame: msg, Err: err}
+    }
+    if parsedMsg == nil {
+        return nil, "", "", &FrameError{Reason: "not a json object", Frame: msg}
+    }
     
     // match _type against regexes to find out if it's an event or a response
     var matches []string
     var messageType string          // "event" or "response"
-    msgType := parsedMsg["_type"].(string)
-    if eventRegex.MatchString(msgType) {
-        matches = eventRegex.FindStringSubmatch(msgType)
+    msgType, ok := parsedMsg["_type"].(string)
+    if !ok {
+        return nil, "", "", &FrameError{Reason: "missing _type", Frame: msg}
+    }
+    if matches = eventRegex.FindStringSubmatch(msgType); matches != nil {
         messageType = EVENT
-    } else {
-        matches = responseRegex.FindStringSubmatch(msgType)
+    } else if matches = responseRegex.FindStringSubmatch(msgType); matches != nil {
         messageType = RESPONSE
+    } else {
+        return nil, "", "", &FrameError{Reason: "unknown _type " + msgType, Frame: msg}
     }
     
-    return parsedMsg, Event(matches[1]), messageType
+    return parsedMsg, Event(matches[1]), messageType, nil
 }
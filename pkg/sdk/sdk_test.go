@@ -0,0 +1,197 @@
+// Copyright © 2022 Relay Inc.
+
+package sdk_test
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"relay-go/pkg/relaytest"
+	"relay-go/pkg/sdk"
+)
+
+// the devices the workflows under test talk to
+const (
+	deviceA = "urn:relay-resource:name:device:alice"
+	deviceB = "urn:relay-resource:name:device:bob"
+)
+
+// Once its deadline expired, Shutdown stops the running instances and returns only after they
+// handled the STOP event.
+func TestShutdownWaitsForStoppedInstances(t *testing.T) {
+	server := relaytest.NewServer()
+	defer server.Close()
+	started := make(chan struct{})
+	var stopped int32
+	server.AddWorkflow("running", func(api sdk.RelayApi) {
+		api.OnStart(func(startEvent sdk.StartEvent) {
+			close(started)
+		})
+		api.OnStop(func(stopEvent sdk.StopEvent) {
+			time.Sleep(100 * time.Millisecond)
+			if stopEvent.Reason == sdk.SHUTDOWN_STOP_REASON {
+				atomic.StoreInt32(&stopped, 1)
+			}
+		})
+	})
+	session, err := server.Dial("running")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer session.Close()
+	session.SendStart(deviceA)
+	<-started
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if err := server.Relay.Shutdown(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Shutdown returned %v, want the deadline error", err)
+	}
+	if atomic.LoadInt32(&stopped) == 0 {
+		t.Error("Shutdown returned before the instance handled the STOP event")
+	}
+
+	if session, err := server.Dial("running"); err == nil {
+		session.Close()
+		t.Error("a workflow was started after Shutdown")
+	}
+}
+
+// A STOP event unblocks the requests waiting for a response with ErrWorkflowStopped, even
+// if their context is not the instance's, and later requests fail without being sent.
+func TestStopCancelsPendingRequests(t *testing.T) {
+	server := relaytest.NewServer()
+	defer server.Close()
+	pending := make(chan error, 1)
+	afterStop := make(chan error, 1)
+	server.AddWorkflow("stopped", func(api sdk.RelayApi) {
+		api.OnStart(func(startEvent sdk.StartEvent) {
+			_, err := api.GetVarCtx(context.Background(), "name", "")
+			pending <- err
+		})
+		api.OnStop(func(stopEvent sdk.StopEvent) {
+			start := time.Now()
+			_, err := api.SetVarCtx(context.Background(), "name", "bob")
+			if elapsed := time.Since(start); elapsed > time.Second {
+				err = errors.New("failed after " + elapsed.String())
+			}
+			afterStop <- err
+		})
+	})
+	session, err := server.Dial("stopped")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer session.Close()
+	// never answered
+	session.Respond("get_var", func(req relaytest.Request) []map[string]interface{} {
+		return nil
+	})
+	session.SendStart(deviceA)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if _, err := session.WaitForRequest(ctx, "get_var"); err != nil {
+		t.Fatal(err)
+	}
+	// stop without closing the websocket, so only the STOP event unblocks the request
+	session.SendEvent(sdk.STOP, map[string]interface{}{"reason": "normal"})
+
+	select {
+	case err := <-pending:
+		if !errors.Is(err, sdk.ErrWorkflowStopped) {
+			t.Errorf("the pending request failed with %v, want ErrWorkflowStopped", err)
+		}
+	case <-ctx.Done():
+		t.Fatal("the pending request was not unblocked by the STOP event")
+	}
+	select {
+	case err := <-afterStop:
+		if !errors.Is(err, sdk.ErrWorkflowStopped) {
+			t.Errorf("a request after STOP failed with %v, want ErrWorkflowStopped", err)
+		}
+	case <-ctx.Done():
+		t.Fatal("a request after STOP did not fail")
+	}
+	if types := session.RequestTypes(); len(types) != 1 {
+		t.Errorf("the workflow sent %v, want only the pending get_var", types)
+	}
+}
+
+// Servers in one process each serve their own workflows under their own path, and the
+// workflows of one are not reachable through the other.
+func TestServersInOneProcess(t *testing.T) {
+	started := make(chan string, 2)
+	serve := func(prefix string) string {
+		server := sdk.NewServer(sdk.WithPathPrefix(prefix))
+		server.AddWorkflow("hello", func(api sdk.RelayApi) {
+			api.OnStart(func(startEvent sdk.StartEvent) {
+				started <- prefix
+			})
+		})
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		if err != nil {
+			t.Fatal(err)
+		}
+		go server.Serve(listener)
+		t.Cleanup(func() {
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			server.Shutdown(ctx)
+		})
+		return "ws://" + listener.Addr().String()
+	}
+	urlA := serve("/a")
+	urlB := serve("/b")
+
+	for _, test := range []struct {
+		url    string
+		prefix string
+	}{
+		{urlA + "/a/hello", "/a"},
+		{urlB + "/b/hello", "/b"},
+	} {
+		session, err := relaytest.Dial(test.url)
+		if err != nil {
+			t.Fatal(err)
+		}
+		session.SendStart(deviceA)
+		select {
+		case prefix := <-started:
+			if prefix != test.prefix {
+				t.Errorf("dialing %s started the workflow of %s", test.url, prefix)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatalf("dialing %s started no workflow", test.url)
+		}
+		session.Close()
+	}
+	for _, url := range []string{urlA + "/b/hello", urlB + "/a/hello"} {
+		if session, err := relaytest.Dial(url); err == nil {
+			session.Close()
+			t.Errorf("dialing %s started a workflow of the other server", url)
+		}
+	}
+}
+
+// Once shut down, a server does not serve again.
+func TestServeAfterShutdown(t *testing.T) {
+	server := sdk.NewServer()
+	if err := server.Shutdown(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := server.Serve(listener); !errors.Is(err, http.ErrServerClosed) {
+		t.Errorf("Serve returned %v, want http.ErrServerClosed", err)
+	}
+	if _, err := listener.Accept(); err == nil {
+		t.Error("Serve did not close the listener")
+	}
+}
@@ -0,0 +1,143 @@
+// Copyright © 2022 Relay Inc.
+
+package sdk
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// The result of a multi-target request for one of its targets.
+type TargetResult[T any] struct {
+	Response T
+	Err      error
+}
+
+// The results of a multi-target request, keyed by target URN.
+type TargetResults[T any] map[string]TargetResult[T]
+
+// Returns a *TargetError listing the targets that failed, or nil if the request succeeded
+// for every target.
+func (results TargetResults[T]) Err() error {
+	errs := make(map[string]error)
+	for targetUri, result := range results {
+		if result.Err != nil {
+			errs[targetUri] = result.Err
+		}
+	}
+	if len(errs) == 0 {
+		return nil
+	}
+	return &TargetError{Errors: errs, Targets: len(results)}
+}
+
+// A TargetError is returned by the Targets variants of the api when the request failed for
+// some of its targets.
+type TargetError struct {
+	Errors  map[string]error // errors by target URN
+	Targets int              // the number of targets of the request
+}
+
+func (targetError *TargetError) Error() string {
+	targetUris := make([]string, 0, len(targetError.Errors))
+	for targetUri := range targetError.Errors {
+		targetUris = append(targetUris, targetUri)
+	}
+	sort.Strings(targetUris)
+	msgs := make([]string, len(targetUris))
+	for i, targetUri := range targetUris {
+		msgs[i] = targetUri + ": " + targetError.Errors[targetUri].Error()
+	}
+	return fmt.Sprintf("request failed for %d of %d targets: %s", len(targetUris), targetError.Targets, strings.Join(msgs, "; "))
+}
+
+// The most requests a Targets variant of the api has in flight at once. The Relay server
+// answers a request with a single response, even if it lists several targets, so the Targets
+// variants send a request of their own to each target to learn which of them failed: a list of
+// 200 targets takes 200 requests, of which at most MaxTargetRequests are awaited at a time.
+const MaxTargetRequests = 8
+
+// Sends the request with send to each of targetUris, MaxTargetRequests at a time, and returns
+// the result for each target. The error is ErrNoTargets if targetUris is empty, or a
+// *TargetError if the request failed for any of the targets. The targets not sent to once ctx
+// is done fail with its error.
+func requestTargets[T any](ctx context.Context, wfInst *workflowInstance, targetUris []string, send func(target map[string][]string) (T, error)) (TargetResults[T], error) {
+	if len(targetUris) == 0 {
+		return nil, ErrNoTargets
+	}
+	// a request takes a slot until it completed, so a new one starts once another completed
+	slots := make(chan struct{}, MaxTargetRequests)
+	futures := make(map[string]*Future[T], len(targetUris))
+	for _, targetUri := range targetUris {
+		if _, ok := futures[targetUri]; ok {
+			continue
+		}
+		targetUri := targetUri
+		slots <- struct{}{}
+		futures[targetUri] = newFuture(wfInst, func() (T, error) {
+			defer func() { <-slots }()
+			if err := ctx.Err(); err != nil {
+				var res T
+				return res, err
+			}
+			return send(makeTargetMap(targetUri))
+		})
+	}
+	results := make(TargetResults[T], len(futures))
+	for targetUri, future := range futures {
+		<-future.Done()
+		results[targetUri] = TargetResult[T]{Response: future.res, Err: future.err}
+	}
+	return results, results.Err()
+}
+
+// Makes all of the target devices 'speak' the text to their users. Returns the result for
+// each target, and a *TargetError if it fails for any of them.
+func (wfInst *workflowInstance) SayTargets(ctx context.Context, targetUris []string, text string, lang Language) (TargetResults[SayResponse], error) {
+	if lang == "" {
+		lang = ENGLISH
+	}
+	wfInst.Logger.Debug("saying ", text, " to ", targetUris, " with lang ", lang)
+	return requestTargets(ctx, wfInst, targetUris, func(target map[string][]string) (SayResponse, error) {
+		return wfInst.sayCtx(ctx, target, text, lang)
+	})
+}
+
+// Plays a custom audio file on all of the target devices. Returns the result for each
+// target, and a *TargetError if it fails for any of them.
+func (wfInst *workflowInstance) PlayTargets(ctx context.Context, targetUris []string, filename string) (TargetResults[PlayResponse], error) {
+	wfInst.Logger.Debug("playing file ", filename, " to ", targetUris)
+	return requestTargets(ctx, wfInst, targetUris, func(target map[string][]string) (PlayResponse, error) {
+		return wfInst.playCtx(ctx, target, filename)
+	})
+}
+
+// Makes all of the target devices vibrate in a pattern, see Vibrate. Returns the result for
+// each target, and a *TargetError if it fails for any of them.
+func (wfInst *workflowInstance) VibrateTargets(ctx context.Context, targetUris []string, pattern []int64) (TargetResults[VibrateResponse], error) {
+	wfInst.Logger.Debug("vibrating ", targetUris, " with pattern ", pattern)
+	return requestTargets(ctx, wfInst, targetUris, func(target map[string][]string) (VibrateResponse, error) {
+		return wfInst.vibrateCtx(ctx, target, pattern)
+	})
+}
+
+// Sets an LED effect on all of the target devices, e.g. LED_FLASH with the color and count
+// in args. Returns the result for each target, and a *TargetError if it fails for any of
+// them.
+func (wfInst *workflowInstance) SetLedTargets(ctx context.Context, targetUris []string, effect LedEffect, args LedInfo) (TargetResults[SetLedResponse], error) {
+	wfInst.Logger.Debug("setting leds of ", targetUris, " ", effect, " with args ", args)
+	return requestTargets(ctx, wfInst, targetUris, func(target map[string][]string) (SetLedResponse, error) {
+		return wfInst.setLedCtx(ctx, target, effect, args)
+	})
+}
+
+// Sets the channel of all of the target devices, see SetChannel. Returns the result for
+// each target, and a *TargetError if it fails for any of them.
+func (wfInst *workflowInstance) SetChannelTargets(ctx context.Context, targetUris []string, channelName string, suppressTTS bool, disableHomeChannel bool) (TargetResults[SetChannelResponse], error) {
+	wfInst.Logger.Debug("setting channel of ", targetUris, " to ", channelName, " suppressTTS ", suppressTTS, " disableHomeChannel ", disableHomeChannel)
+	return requestTargets(ctx, wfInst, targetUris, func(target map[string][]string) (SetChannelResponse, error) {
+		return wfInst.setChannelCtx(ctx, target, channelName, suppressTTS, disableHomeChannel)
+	})
+}
@@ -0,0 +1,157 @@
+// Copyright © 2022 Relay Inc.
+
+package sdk_test
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"testing"
+	"time"
+
+	"relay-go/pkg/relaytest"
+	"relay-go/pkg/sdk"
+)
+
+type targetsResult struct {
+	results sdk.TargetResults[sdk.SetLedResponse]
+	err     error
+}
+
+func TestTargetsRequest(t *testing.T) {
+	server := relaytest.NewServer()
+	defer server.Close()
+	got := make(chan targetsResult, 3)
+	server.AddWorkflow("targets", func(api sdk.RelayApi) {
+		api.OnStart(func(startEvent sdk.StartEvent) {
+			results, err := api.SetLedTargets(api.Context(), nil, sdk.LED_OFF, sdk.LedInfo{})
+			got <- targetsResult{results, err}
+			results, err = api.SetLedTargets(api.Context(), []string{deviceA, deviceA}, sdk.LED_OFF, sdk.LedInfo{})
+			got <- targetsResult{results, err}
+			results, err = api.SetLedTargets(api.Context(), []string{deviceA, deviceB}, sdk.LED_OFF, sdk.LedInfo{})
+			got <- targetsResult{results, err}
+		})
+	})
+	session, err := server.Dial("targets")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer session.Close()
+	session.Respond("set_led", func(req relaytest.Request) []map[string]interface{} {
+		if len(req.Target) == 1 && req.Target[0] == deviceB {
+			return []map[string]interface{}{{
+				"_type":        "wf_api_error_response",
+				"_id":          req.Id,
+				"code":         "invalid_target",
+				"message":      "unknown device",
+				"request_type": "wf_api_set_led_request",
+			}}
+		}
+		return session.DefaultResponse(req)
+	})
+	session.SendStart(deviceA)
+
+	var results []targetsResult
+	for len(results) < 3 {
+		select {
+		case result := <-got:
+			results = append(results, result)
+		case <-time.After(5 * time.Second):
+			t.Fatalf("got %d results, want 3", len(results))
+		}
+	}
+	if !errors.Is(results[0].err, sdk.ErrNoTargets) || results[0].results != nil {
+		t.Errorf("without targets, got %v, %v, want ErrNoTargets", results[0].results, results[0].err)
+	}
+	if results[1].err != nil || len(results[1].results) != 1 || results[1].results[deviceA].Err != nil {
+		t.Errorf("for a duplicate target, got %v, %v, want a single result without error", results[1].results, results[1].err)
+	}
+
+	var targetError *sdk.TargetError
+	if !errors.As(results[2].err, &targetError) {
+		t.Fatalf("got %v, want a *TargetError", results[2].err)
+	}
+	if targetError.Targets != 2 || len(targetError.Errors) != 1 {
+		t.Errorf("got %v, want an error for 1 of 2 targets", targetError)
+	}
+	if err := results[2].results[deviceA].Err; err != nil {
+		t.Errorf("for %s, got %v, want no error", deviceA, err)
+	}
+	var relayError *sdk.RelayError
+	if !errors.As(results[2].results[deviceB].Err, &relayError) || relayError.Code != "invalid_target" {
+		t.Errorf("for %s, got %v, want a *RelayError", deviceB, results[2].results[deviceB].Err)
+	}
+
+	// one request per distinct target
+	var targets []string
+	for _, req := range session.Requests() {
+		if len(req.Target) != 1 {
+			t.Fatalf("sent a request to %v, want a single target", req.Target)
+		}
+		targets = append(targets, req.Target[0])
+	}
+	sort.Strings(targets)
+	if want := []string{deviceA, deviceA, deviceB}; len(targets) != len(want) || targets[0] != want[0] || targets[1] != want[1] || targets[2] != want[2] {
+		t.Errorf("sent requests to %v, want %v", targets, want)
+	}
+}
+
+// A Targets request to many targets has at most MaxTargetRequests requests in flight, and
+// sends the next one as soon as another is answered.
+func TestTargetsRequestsAreBounded(t *testing.T) {
+	server := relaytest.NewServer()
+	defer server.Close()
+	targetUris := make([]string, 3*sdk.MaxTargetRequests)
+	for i := range targetUris {
+		targetUris[i] = sdk.DeviceName(fmt.Sprint("device", i))
+	}
+	got := make(chan error, 1)
+	server.AddWorkflow("targets", func(api sdk.RelayApi) {
+		api.OnStart(func(startEvent sdk.StartEvent) {
+			_, err := api.VibrateTargets(api.Context(), targetUris, []int64{100})
+			got <- err
+		})
+	})
+	session, err := server.Dial("targets")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer session.Close()
+	sent := make(chan string, len(targetUris))
+	session.Respond("vibrate", func(req relaytest.Request) []map[string]interface{} {
+		sent <- req.Id
+		return nil
+	})
+	session.SendStart(deviceA)
+
+	var inFlight []string
+	most := 0
+	for answered := 0; answered < len(targetUris); {
+		select {
+		case id := <-sent:
+			inFlight = append(inFlight, id)
+			if len(inFlight) > most {
+				most = len(inFlight)
+			}
+		case <-time.After(50 * time.Millisecond):
+			if len(inFlight) == 0 {
+				t.Fatalf("%d of %d requests were sent", answered, len(targetUris))
+			}
+			// answer one, which lets the next request go
+			session.Send(map[string]interface{}{"_type": "wf_api_vibrate_response", "_id": inFlight[0]})
+			inFlight = inFlight[1:]
+			answered++
+		}
+	}
+	if most != sdk.MaxTargetRequests {
+		t.Errorf("at most %d requests were in flight, want %d", most, sdk.MaxTargetRequests)
+	}
+	select {
+	case err := <-got:
+		if err != nil {
+			t.Error(err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("VibrateTargets did not return")
+	}
+}
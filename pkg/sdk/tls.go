@@ -0,0 +1,118 @@
+// Copyright © 2022 Relay Inc.
+
+package sdk
+
+import (
+	"crypto/tls"
+	"os"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// how often the certificate files are checked for changes, at most, a variable so tests can
+// shorten it
+var certReloadInterval = 10 * time.Second
+
+// Serves the workflows over TLS (wss) with the certificate and key in the given PEM files.
+// The files are checked for changes while serving, and the certificate is reloaded when
+// they are replaced on disk, i.e. after a renewal.  Can be combined with WithTLSConfig.
+func WithTLS(certFile string, keyFile string) ServerOption {
+	return func(server *Server) {
+		server.certFile = certFile
+		server.keyFile = keyFile
+	}
+}
+
+// Serves the workflows over TLS (wss) with the given configuration.  The configuration
+// must provide a certificate unless WithTLS is also used.
+func WithTLSConfig(config *tls.Config) ServerOption {
+	return func(server *Server) {
+		server.tlsConfig = config
+	}
+}
+
+// builds the TLS configuration for the listener, or returns nil if TLS is not enabled
+func (server *Server) serverTLSConfig() (*tls.Config, error) {
+	if server.tlsConfig == nil && server.certFile == "" {
+		return nil, nil
+	}
+	var config *tls.Config
+	if server.tlsConfig != nil {
+		config = server.tlsConfig.Clone()
+	} else {
+		config = &tls.Config{MinVersion: tls.VersionTLS12}
+	}
+	if server.certFile != "" {
+		reloader, err := newCertReloader(server.certFile, server.keyFile, server.logger)
+		if err != nil {
+			return nil, err
+		}
+		config.Certificates = nil
+		config.GetCertificate = reloader.GetCertificate
+	}
+	// websockets are upgraded from HTTP/1.1
+	config.NextProtos = []string{"http/1.1"}
+	return config, nil
+}
+
+// certReloader serves a certificate loaded from files, and reloads it when the files change.
+type certReloader struct {
+	certFile string
+	keyFile  string
+	logger   log.FieldLogger
+
+	mutex       sync.Mutex
+	cert        *tls.Certificate
+	certModTime time.Time
+	keyModTime  time.Time
+	checkedAt   time.Time
+}
+
+func newCertReloader(certFile string, keyFile string, logger log.FieldLogger) (*certReloader, error) {
+	reloader := &certReloader{certFile: certFile, keyFile: keyFile, logger: logger}
+	if err := reloader.reload(); err != nil {
+		return nil, err
+	}
+	return reloader, nil
+}
+
+// GetCertificate implements tls.Config.GetCertificate.
+func (reloader *certReloader) GetCertificate(hello *tls.ClientHelloInfo) (*tls.Certificate, error) {
+	reloader.mutex.Lock()
+	defer reloader.mutex.Unlock()
+	if time.Since(reloader.checkedAt) >= certReloadInterval {
+		if err := reloader.reload(); err != nil {
+			// keep serving the certificate we have
+			reloader.logger.Error("error reloading TLS certificate: ", err)
+		}
+	}
+	return reloader.cert, nil
+}
+
+// loads the key pair if either file changed since the last load, must be called with the mutex held
+func (reloader *certReloader) reload() error {
+	reloader.checkedAt = time.Now()
+	certInfo, err := os.Stat(reloader.certFile)
+	if err != nil {
+		return err
+	}
+	keyInfo, err := os.Stat(reloader.keyFile)
+	if err != nil {
+		return err
+	}
+	if reloader.cert != nil && certInfo.ModTime().Equal(reloader.certModTime) && keyInfo.ModTime().Equal(reloader.keyModTime) {
+		return nil
+	}
+
+	cert, err := tls.LoadX509KeyPair(reloader.certFile, reloader.keyFile)
+	if err != nil {
+		return err
+	}
+	reloader.cert = &cert
+	reloader.certModTime = certInfo.ModTime()
+	reloader.keyModTime = keyInfo.ModTime()
+	reloader.logger.Info("loaded TLS certificate from ", reloader.certFile)
+	return nil
+}
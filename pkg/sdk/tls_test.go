@@ -0,0 +1,113 @@
+// Copyright © 2022 Relay Inc.
+
+package sdk
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	log "github.com/sirupsen/logrus"
+)
+
+// writes a self-signed certificate for localhost with the common name, and its key, as PEM
+// files, and gives them the modification time
+func writeCert(t *testing.T, certFile string, keyFile string, commonName string, modTime time.Time) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: commonName},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer}), 0600); err != nil {
+		t.Fatal(err)
+	}
+	for _, file := range []string{certFile, keyFile} {
+		if err := os.Chtimes(file, modTime, modTime); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+// connects to the workflow over wss, and returns the common name of the server's certificate
+func dialTLS(t *testing.T, url string) string {
+	t.Helper()
+	dialer := websocket.Dialer{TLSClientConfig: &tls.Config{InsecureSkipVerify: true}}
+	conn, _, err := dialer.Dial(url, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	state := conn.UnderlyingConn().(*tls.Conn).ConnectionState()
+	return state.PeerCertificates[0].Subject.CommonName
+}
+
+// A server configured with WithTLS serves wss, and serves the new certificate once the files
+// were replaced and the reload interval passed.
+func TestTLSCertificateReload(t *testing.T) {
+	interval := certReloadInterval
+	certReloadInterval = 10 * time.Millisecond
+	defer func() { certReloadInterval = interval }()
+
+	dir := t.TempDir()
+	certFile := filepath.Join(dir, "cert.pem")
+	keyFile := filepath.Join(dir, "key.pem")
+	writeCert(t, certFile, keyFile, "first", time.Now().Add(-time.Minute))
+
+	logger := log.New()
+	logger.SetOutput(io.Discard)
+	server := NewServer(WithTLS(certFile, keyFile), WithLogger(logger))
+	server.AddWorkflow("hello", func(api RelayApi) {})
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	go server.Serve(listener)
+	defer func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		server.Shutdown(ctx)
+	}()
+	url := "wss://" + listener.Addr().String() + "/hello"
+
+	if name := dialTLS(t, url); name != "first" {
+		t.Fatalf("the server presented %q, want the first certificate", name)
+	}
+	writeCert(t, certFile, keyFile, "second", time.Now())
+	time.Sleep(2 * certReloadInterval)
+	if name := dialTLS(t, url); name != "second" {
+		t.Errorf("the server presented %q after the files changed, want the second certificate", name)
+	}
+}
@@ -2,6 +2,8 @@
 
 package sdk
 
+//go:generate go run ../../internal/cmd/protogen -schema protocol.json -out protocol_gen.go -test protocol_gen_test.go
+
 // Different events that can happen during a workflow, including
 // an error, interaction lifecycle events, button presses, timers
 // or notifications, incidents, speech, and calls. See the Relay Guide's
@@ -81,6 +83,23 @@ const (
 
 	// A named timer has fired.
 	TIMER_FIRED = "timer_fired"
+
+	// The device we called is making progress on getting connected. This may
+	// be interspersed with CALL_RINGING. This event can occur on the caller.
+	CALL_PROGRESSING = "call_progressing"
+
+	// A long running action is being performed across a large number of devices,
+	// this event may occur multiple times.
+	PROGRESS = "progress"
+
+	// A missed message is being played from the device's inbox.
+	PLAY_INBOX_MESSAGES = "play_inbox_messages"
+
+	// An SMS message has been received or sent.
+	SMS = "sms"
+
+	// Your workflow has been resumed.
+	RESUME = "resume"
 )
 
 // The different types of triggers that can start a workflow.
@@ -182,26 +201,16 @@ const (
 
 // EVENTS
 
-// event structs are exported, but their _type and _id are not
-
-type StartEvent struct {
-	_type   string `json:"_type"`
-	Trigger Trigger
-}
+// The event, request and response structs are generated into protocol_gen.go from the
+// description of the wire protocol in protocol.json; run go generate after changing it.
+// Event and response structs are exported, requests are not, because they are created
+// internally.
 
 type Trigger struct {
 	Type TriggerType
 	Args TriggerArgs
 }
 
-type NotificationEvent struct {
-	_type             string `json:"_type"`
-	Name              string `json:"name"`
-	Event             string `json:"event"`
-	SourceUri         string `json:"source_uri"`
-	NotificationState string `json:"notification_state"`
-}
-
 type NotificationOptions struct {
 	Priority NotificationPriority
 	Title    string
@@ -224,430 +233,13 @@ const (
 	SOS     = `sos`
 )
 
-type ProgressEvent struct {
-	_type string `json:"_type"`
-}
-
-type PlayInboxMessagesEvent struct {
-	_type  string `json:"_type"`
-	Action string `json:"action"`
-}
-
-type CallConnectedEvent struct {
-	_type            string `json:"_type"`
-	CallId           string `json:"call_id"`
-	Direction        string `json:"direction"`
-	DeviceId         string `json:"device_id"`
-	DeviceName       string `json:"device_name"`
-	Uri              string `json:"uri"`
-	OnNet            string `json:"onnet"`
-	StartTimeEpoch   string `json:"start_time_epoch"`
-	ConnectTimeEpoch string `json:"connect_time_epoch"`
-}
-
-type CallDisconnectedEvent struct {
-	_type            string `json:"_type"`
-	CallId           string `json:"call_id"`
-	Direction        string `json:"direction"`
-	DeviceId         string `json:"device_id"`
-	DeviceName       string `json:"device_name"`
-	Uri              string `json:"uri"`
-	OnNet            string `json:"onnet"`
-	Reason           string `json:"reason"`
-	StartTimeEpoch   int64 `json:"start_time_epoch"`
-	ConnectTimeEpoch int64 `json:"connect_time_epoch"`
-	EndTimeEpoch     int64 `json:"end_time_epoch"`
-}
-
-type CallFailedEvent struct {
-	_type            string `json:"_type"`
-	CallId           string `json:"call_id"`
-	Direction        string `json:"direction"`
-	DeviceId         string `json:"device_id"`
-	DeviceName       string `json:"device_name"`
-	Uri              string `json:"uri"`
-	OnNet            string `json:"onnnet"`
-	Reason           string `json:"reason"`
-	StartTimeEpoch   string `json:"start_time_epoch"`
-	ConnectTimeEpoch string `json:"connect_time_epoch"`
-	EndTimeEpoch     string `json:"end_time_epoch"`
-}
-
-type CallReceivedEvent struct {
-	_type          string `json:"_type"`
-	CallId         string `json:"call_id"`
-	Direction      string `json:"direction"`
-	DeviceId       string `json:"device_id"`
-	DeviceName     string `json:"device_name"`
-	Uri            string `json:"uri"`
-	OnNet          string `json:"onnet"`
-	StartTimeEpoch string `json:"start_time_epoch"`
-}
-
-type CallRingingEvent struct {
-	_type          string `json:"_type"`
-	CallId         string `json:"call_id"`
-	Direction      string `json:"direction"`
-	DeviceId       string `json:"device_id"`
-	DeviceName     string `json:"device_name"`
-	Uri            string `json:"uri"`
-	OnNet          string `json:"onnet"`
-	StartTimeEpoch string `json:"start_time_epoch"`
-}
-
-type CallStartEvent struct {
-	_type string `json:"_type"`
-	Uri   string `json:"uri"`
-}
-
-type CallProgressingEvent struct {
-	_type          string `json:"_type"`
-	CallId         string `json:"call_id"`
-	Direction      string `json:"direction"`
-	DeviceId       string `json:"device_id"`
-	DeviceName     string `json:"device_name"`
-	Uri            string `json:"uri"`
-	OnNet          string `json:"onnet"`
-	StartTimeEpoch string `json:"start_time_epoch"`
-}
-
-type SmsEvent struct {
-	Id    string `json:"id"`
-	Event string `json:"event"`
-}
-
-type IncidentEvent struct {
-	_type      string `json:"_type"`
-	Type       string `json:"type"`
-	IncidentId string `json:"incident_id"`
-	Reason     string `json:"reason"`
-}
-
-type ResumeEvent struct {
-	_type string `json:"_type"`
-}
-
 type TriggerArgs struct {
 	Phrase    string `json:"phrase"`
 	SourceUri string `json:"source_uri"`
 }
 
-type InteractionLifecycleEvent struct {
-	_type         string `json:"_type"`
-	SourceUri     string `json:"source_uri"`
-	LifecycleType string `json:"type"` // started, resumed
-}
-
-type PromptEvent struct {
-	_type      string `json:"_type"`
-	SourceUri  string `json:"source_uri"`
-	PromptType string `json:"type"` // started, stopped, resumed
-}
-
-type TimerFiredEvent struct {
-	_type string `json:"_type"`
-	Name  string `json:"name"`
-}
-
-type TimerEvent struct {
-	_type string `json:"_type"`
-}
-
-type ButtonEvent struct {
-	_type     string `json:"_type"`
-	SourceUri string `json:"source_uri"`
-	Button    string `json:"button"` // "action", "channel"
-	Taps      string `json:"taps"`   // "single", "double", "triple", "long"
-}
-
-type StopEvent struct {
-	_type  string `json:"_type"`
-	Reason string `json:"reason"`
-}
-
-type SpeechEvent struct {
-	_id       string `json:"_id"`
-	_type     string `json:"_type"`
-	SourceUri string `json:"source_uri"`
-	ReuqestId string `json:"request_id"`
-	Text      string `json:"text"`
-	Audio     string `json:"audio"`
-	Lang      string `json:"lang"`
-}
-
 // REQUEST/RESPONSE
 
-// requests are not exported, because they are created internally, and their _type and _id fields are exported so they can be json encoded
-// responses are exported, but their _type and _id are not, because they are not needed by user
-
-type startInteractionRequest struct {
-	Type    string              `json:"_type"`
-	Id      string              `json:"_id"`
-	Targets map[string][]string `json:"_target"`
-	Name    string              `json:"name"`
-	//Options ??
-}
-
-type StartInteractionResponse struct {
-	_type     string `json:"_type"`
-	_id       string `json:"_id"`
-	SourceUri string `json:"source_uri"`
-}
-
-type endInteractionRequest struct {
-	Type    string              `json:"_type"`
-	Id      string              `json:"_id"`
-	Targets map[string][]string `json:"_target"`
-	//Options ??
-}
-
-type EndInteractionResponse struct {
-	_type     string `json:"_type"`
-	_id       string `json:"_id"`
-	SourceUri string `json:"source_uri"`
-}
-
-type sayRequest struct {
-	Type   string              `json:"_type"`
-	Id     string              `json:"_id"`
-	Target map[string][]string `json:"_target"`
-	Text   string              `json:"text"`
-	Lang   Language            `json:"lang"`
-}
-
-type SayResponse struct {
-	_type         string `json:"_type"`
-	_id           string `json:"_id"`
-	CorrelationId string `json:"id"`
-}
-
-type listenRequest struct {
-	Id         string              `json:"_id"`
-	Target     map[string][]string `json:"_target"`
-	Type       string              `json:"_type"`
-	ReqestId   string              `json:"request_id"`
-	Phrases    []string            `json:"phrases"`
-	Transcribe bool                `json:"transcribe"`
-	Timeout    int                 `json:"timeout"`
-	AltLang    string              `json:"alt_lang"`
-}
-
-type ListenResponse struct {
-	_id   string `json:"_id"`
-	_type string `json:"_type"`
-}
-
-type translateRequest struct {
-	Id       string   `json:"_id"`
-	Type     string   `json:"_type"`
-	Text     string   `json:"text"`
-	FromLang Language `json:"from_lang"`
-	ToLang   Language `json:"to_lang"`
-}
-
-type TranslateResponse struct {
-	_id   string `json:"_id"`
-	_type string `json:"_type"`
-	Text  string `json:"text"`
-}
-
-type logAnalyticsEventRequest struct {
-	Id          string `json:"_id"`
-	Type        string `json:"_type"`
-	Content     string `json:"content"`
-	ContentType string `json:"content_type"`
-	Category    string `json:"category"`
-	DeviceUri   string `json":"device_uri,omitempty"`
-}
-
-type LogAnalyticsEventResponse struct {
-	_id   string `json:"_id"`
-	_type string `json:"_type"`
-}
-
-type setVarRequest struct {
-	Id    string `json:"_id"`
-	Type  string `json:"_type"`
-	Name  string `json:"name"`
-	Value string `json:"value"`
-}
-
-type SetVarResponse struct {
-	_id   string `json:"_id"`
-	_type string `json:"_type"`
-	Name  string `json:"name"`
-	IType string `json:"type"`
-	Value string `json:"value"`
-}
-
-type unsetVarRequest struct {
-	Id   string `json:"_id"`
-	Type string `json:"_type"`
-	Name string `json:"name"`
-}
-
-type UnsetVarResponse struct {
-	_id   string `json:"_id"`
-	_type string `json:"_type"`
-}
-
-type getVarRequest struct {
-	Id   string `json:"_id"`
-	Type string `json:"_type"`
-	Name string `json:"name"`
-}
-
-type GetVarResponse struct {
-	_id   string `json:"_id"`
-	_type string `json:"_type"`
-	Value string `json:"value"`
-}
-
-type playRequest struct {
-	Type     string              `json:"_type"`
-	Id       string              `json:"_id"`
-	Target   map[string][]string `json:"_target"`
-	Filename string              `json:"filename"`
-}
-
-type PlayResponse struct {
-	_type         string `json:"_type"`
-	_id           string `json:"_id"`
-	CorrelationId string `json:"id"`
-}
-
-type stopPlaybackRequest struct {
-	Type   string              `json:"_type"`
-	Id     string              `json:"_id"`
-	Target map[string][]string `json:"_target"`
-	Ids    []string            `json:"ids"`
-}
-
-type StopPlaybackResponse struct {
-	_type string `json:"_type"`
-	_id   string `json:"_id"`
-}
-
-type inboxCountRequest struct {
-	Id     string              `json:"_id"`
-	Target map[string][]string `json:"_target"`
-	Type   string              `json:"_type"`
-}
-
-type InboxCountResponse struct {
-	_id   string `json:"_id"`
-	_type string `json:"_type"`
-	Count string `json:"count"`
-}
-
-type playInboxMessagesRequest struct {
-	Id     string              `json:"_id"`
-	Target map[string][]string `json:"_target"`
-	Type   string              `json:"_type"`
-}
-
-type PlayInboxMessagesResponse struct {
-	_id   string `json:"_id"`
-	_type string `json:"_type"`
-}
-
-type setHomeChannelStateRequest struct {
-	Type    string              `json:"_type"`
-	Id      string              `json:"_id"`
-	Target  map[string][]string `json:"_target"`
-	Enabled bool                `json:"enabled"`
-}
-
-type SetHomeChannelStateResponse struct {
-	_type string `json:"_type"`
-	Id    string `json:"_id"`
-}
-
-type setTimerRequest struct {
-	Type        string      `json:"_type"`
-	Id          string      `json:"_id"`
-	TimerType   TimerType   `json:"type"`
-	Name        string      `json:"name"`
-	Timeout     uint64      `json:"timeout"`
-	TimeoutType TimeoutType `json:"timeout_type"`
-}
-
-type SetTimerResponse struct {
-	_type string `json:"_type"`
-	_id   string `json:"_id"`
-}
-
-type clearTimerRequest struct {
-	Type string `json:"_type"`
-	Id   string `json:"_id"`
-	Name string `json:"name"`
-}
-
-type ClearTimerResponse struct {
-	_type string `json:"_type"`
-	_id   string `json:"_id"`
-}
-
-type startTimerRequest struct {
-	Id      string `json:"_id"`
-	Type    string `json:"_type"`
-	Timeout int    `json:"timeout"`
-}
-
-type StartTimerResponse struct {
-	_id   string `json:"_id"`
-	_type string `json:"_type"`
-}
-
-type stopTimerRequest struct {
-	Id   string `json:"_id"`
-	Type string `json:"_type"`
-}
-
-type StopTimerResponse struct {
-	_id   string `json"_id"`
-	_type string `json:"_type"`
-}
-
-type createIncidentRequest struct {
-	Id            string `json:"_id"`
-	Type          string `json:"_type"`
-	IncidentType  string `json:"type"`
-	OriginatorUri string `json:"originator_uri"`
-}
-
-type CreateIncidentResponse struct {
-	_id        string `json:"_id"`
-	_type      string `json:"_type"`
-	IncidentId string `json:"incident_id"`
-}
-
-type resolveIncidentRequest struct {
-	Id         string `json:"_id"`
-	Type       string `json:"_type"`
-	IncidentId string `json:"incident_id"`
-	Reason     string `json:"reason"`
-}
-
-type ResolveIncidentResponse struct {
-	_id   string `json:"_id"`
-	_type string `json:"_type"`
-}
-
-type groupQueryRequest struct {
-	Id       string `json:"_id"`
-	Type     string `json:"_type"`
-	GroupUri string `json:"group_uri"`
-	Query    string `json:"query"`
-}
-
-type GroupQueryResponse struct {
-	_id        string   `json:"_id"`
-	_type      string   `json:"_type"`
-	MemberUris []string `json:"member_uris"`
-	IsMember   bool     `json:"is_member"`
-}
-
 type LedEffect string
 
 const (
@@ -659,14 +251,6 @@ const (
 	LED_OFF     = "off"
 )
 
-type setLedRequest struct {
-	Type   string              `json:"_type"`
-	Id     string              `json:"_id"`
-	Target map[string][]string `json:"_target"`
-	Effect LedEffect           `json:"effect"`
-	Args   LedInfo             `json:"args"`
-}
-
 type LedInfo struct {
 	Rotations      int64     `json:"rotations,omitempty"`
 	Count          int64     `json:"count,omitempty"`
@@ -748,62 +332,6 @@ func setLedColors(index string, color string) LedColors {
 	return LedColors{Led1: color}
 }
 
-type SetLedResponse struct {
-	_type string `json:"_type"`
-	_id   string `json:"_id"`
-}
-
-type vibrateRequest struct {
-	Type    string              `json:"_type"`
-	Id      string              `json:"_id"`
-	Target  map[string][]string `json:"_target"`
-	Pattern []int64            `json:"pattern"`
-}
-
-type VibrateResponse struct {
-	_type string `json:"_type"`
-	_id   string `json:"_id"`
-}
-
-type sendNotificationRequest struct {
-	Type        string              `json:"_type"`
-	Id          string              `json:"_id"`
-	Target      map[string][]string `json:"_target"`
-	Originator  string              `json:"originator"`
-	IType       string              `json:"type"`
-	Name        string              `json:"name"`
-	Text        string              `json:"text"`
-	ITarget     map[string][]string `json:"target"`
-	PushOptions NotificationOptions `json:"push_opts"`
-}
-
-type SendNotificationResponse struct {
-	_id   string `json:"_id"`
-	_type string `json:"_type"`
-}
-
-type getDeviceInfoRequest struct {
-	Type    string              `json:"_type"`
-	Id      string              `json:"_id"`
-	Target  map[string][]string `json:"_target"`
-	Query   DeviceInfoQuery     `json:"query"`
-	Refresh bool                `json:"refresh"`
-}
-
-type GetDeviceInfoResponse struct {
-	_type           string    `json:"_type"`
-	_id             string    `json:"_id"`
-	Name            string    `json:"name"`
-	Id              string    `json:"id"`
-	Address         string    `json:"address"`
-	LatLong         []float64 `json:"latlong"`
-	IndoorLocation  string    `json:"indoor_location"`
-	Battery         uint64    `json:"battery"`
-	Type            string    `json:"type"`
-	Username        string    `json:"username"`
-	LocationEnabled bool      `json:"location_enabled"`
-}
-
 type SetDeviceInfoType string
 
 const (
@@ -811,58 +339,6 @@ const (
 	SET_DEVICE_INFO_LOCATION_ENABLED = "location_enabled"
 )
 
-type setDeviceInfoRequest struct {
-	Type   string              `json:"_type"`
-	Id     string              `json:"_id"`
-	Target map[string][]string `json:"_target"`
-	Field  SetDeviceInfoType   `json:"field"`
-	Value  string              `json:"value"`
-}
-
-type SetDeviceInfoResponse struct {
-	_type string `json:"_type"`
-	_id   string `json:"_id"`
-}
-
-type setUserProfileRequest struct {
-	Type     string              `json:"_type"`
-	Id       string              `json:"_id"`
-	Target   map[string][]string `json:"_target"`
-	Username string              `json:"username"`
-	Force    bool                `json:"force"`
-}
-
-type SetUserProfileResponse struct {
-	_type string `json:"_type"`
-	_id   string `json:"_id"`
-}
-
-type setChannelRequest struct {
-	Type               string              `json:"_type"`
-	Id                 string              `json:"_id"`
-	Target             map[string][]string `json:"_target"`
-	ChannelName        string              `json:"channel_name"`
-	SuppressTTS        bool                `json:"suppress_tts"`
-	DisableHomeChannel bool                `json:"disable_home_channel"`
-}
-
-type SetChannelResponse struct {
-	_type string `json:"_type"`
-	_id   string `json:"_id"`
-}
-
-type setDeviceModeRequest struct {
-	Type   string              `json:"_type"`
-	Id     string              `json:"_id"`
-	Target map[string][]string `json:"_target"`
-	Mode   DeviceMode          `json:"mode"`
-}
-
-type SetDeviceModeResponse struct {
-	_type string `json:"_type"`
-	_id   string `json:"_id"`
-}
-
 type DeviceMode string
 
 const (
@@ -871,56 +347,3 @@ const (
 	DEVICE_MODE_NONE  = "none"
 )
 
-type devicePowerOffRequest struct {
-	Type    string              `json:"_type"`
-	Id      string              `json:"_id"`
-	Target  map[string][]string `json:"_target"`
-	Restart bool                `json:"restart"`
-}
-
-type DevicePowerOffResponse struct {
-	_type string `json:"_type"`
-	_id   string `json:"_id"`
-}
-
-type placeCallRequest struct {
-	Id     string              `json:"_id"`
-	Type   string              `json:"_type"`
-	Target map[string][]string `json:"_target"`
-	Uri    string              `json:"uri"`
-}
-
-type PlaceCallResponse struct {
-	_id     string `json:"_id"`
-	_type   string `json:"_type"`
-	call_id string `json:"call_id"`
-}
-
-type hangupCallRequest struct {
-	Id     string              `json:"_id"`
-	Target map[string][]string `json:"_target"`
-	Type   string              `json:"_type"`
-	CallId string              `json:"call_id"`
-}
-
-type HangupCallResponse struct {
-	_id   string `json:"_id"`
-	_type string `json:"_type"`
-}
-
-type answerRequest struct {
-	Id     string              `json:"_id"`
-	Target map[string][]string `json:"_target"`
-	Type   string              `json:"_type"`
-	CallId string              `json:"call_id"`
-}
-
-type AnswerResponse struct {
-	_id   string `json:"_id"`
-	_type string `json:"_type"`
-}
-
-type terminateRequest struct {
-	Type string `json:"_type"`
-	Id   string `json:"_id"`
-}
@@ -0,0 +1,175 @@
+// Copyright © 2022 Relay Inc.
+
+package sdk
+
+import (
+	"context"
+)
+
+// A goroutine blocked in WaitForEvent.
+type eventWaiter struct {
+	event   Event
+	filters []EventFilter
+	ch      chan EventWrapper
+}
+
+// Blocks until an event of the given type that matches all of the filters arrives, and
+// returns it. The event is taken by the waiter and not passed to the OnXxx handlers, except
+// for START and STOP events, which the handlers always see. The handlers added with Subscribe
+// get it as well, on the goroutine that handles events. If several goroutines wait for
+// the same event, the one that started waiting first gets it. Returns an error if ctx is
+// done or the workflow instance stops first.
+//
+// An event that arrived before the call and is still in the event queue, i.e. a button
+// pressed while the handler was in SayAndWait, is returned right away; the oldest match
+// is taken. START and STOP events in the queue are left for the handlers. Otherwise the
+// event is taken as soon as it is read from the websocket, so a handler can wait for an
+// event while other events queue up behind it.
+func (wfInst *workflowInstance) WaitForEvent(ctx context.Context, event Event, filters ...EventFilter) (EventWrapper, error) {
+	waiter := &eventWaiter{event: event, filters: filters, ch: make(chan EventWrapper, 1)}
+	if eventWrapper, ok := wfInst.takeQueuedOrRegister(waiter); ok {
+		return eventWrapper, nil
+	}
+	// the event may arrive behind events the event queue is holding back
+	wfInst.EventQueue.wake()
+
+	var err error
+	select {
+	case eventWrapper := <-waiter.ch:
+		return eventWrapper, nil
+	case <-ctx.Done():
+		err = ctx.Err()
+		if wfInst.Ctx.Err() != nil {
+			// ctx was derived from the instance context
+			err = ErrWorkflowStopped
+		}
+	case <-wfInst.Ctx.Done():
+		err = ErrWorkflowStopped
+	}
+
+	wfInst.Mutex.Lock()
+	wfInst.removeWaiter(waiter)
+	wfInst.Mutex.Unlock()
+	// the event may have been delivered while giving up
+	select {
+	case eventWrapper := <-waiter.ch:
+		return eventWrapper, nil
+	default:
+		return EventWrapper{}, err
+	}
+}
+
+// Blocks until the button of the device or interaction URN is tapped taps times, i.e.
+// "double", and returns the button event. An empty taps matches any. See WaitForEvent.
+func (wfInst *workflowInstance) WaitForButton(ctx context.Context, sourceUri string, taps string) (ButtonEvent, error) {
+	eventWrapper, err := wfInst.WaitForEvent(ctx, BUTTON, SourceFilter(sourceUri), ButtonFilter("", taps))
+	if err != nil {
+		return ButtonEvent{}, err
+	}
+	return DecodeEvent[ButtonEvent](eventWrapper)
+}
+
+// Blocks until the named timer fires, and returns the timer fired event. See WaitForEvent.
+func (wfInst *workflowInstance) WaitForTimer(ctx context.Context, name string) (TimerFiredEvent, error) {
+	eventWrapper, err := wfInst.WaitForEvent(ctx, TIMER_FIRED, TimerFilter(name))
+	if err != nil {
+		return TimerFiredEvent{}, err
+	}
+	return DecodeEvent[TimerFiredEvent](eventWrapper)
+}
+
+// Takes the oldest queued event the waiter matches, and marks it as waited, so the OnXxx
+// handlers skip it; it stays queued only if somebody subscribed to it. If there is none,
+// registers the waiter while the queue is still locked, so an event read meanwhile reaches
+// the waiter. Returns false if the waiter was registered.
+func (wfInst *workflowInstance) takeQueuedOrRegister(waiter *eventWaiter) (EventWrapper, bool) {
+	var taken EventWrapper
+	found := false
+	wfInst.EventQueue.withEvents(func(events []EventWrapper) int {
+		for i := range events {
+			queued := &events[i]
+			if queued.waited || lifecycleEvent(queued.EventName) || !waiter.matches(*queued) {
+				continue
+			}
+			taken, found = *queued, true
+			queued.waited = true
+			if wfInst.Subscriptions.has(queued.EventName) {
+				return -1
+			}
+			return i
+		}
+		wfInst.Mutex.Lock()
+		wfInst.Waiters = append(wfInst.Waiters, waiter)
+		wfInst.Mutex.Unlock()
+		return -1
+	})
+	return taken, found
+}
+
+// Passes the event to the first waiter it matches. Returns true if a waiter took it.
+func (wfInst *workflowInstance) deliverToWaiter(eventWrapper EventWrapper) bool {
+	wfInst.Mutex.Lock()
+	var match *eventWaiter
+	for _, waiter := range wfInst.Waiters {
+		if waiter.matches(eventWrapper) {
+			match = waiter
+			break
+		}
+	}
+	if match != nil {
+		wfInst.removeWaiter(match)
+	}
+	wfInst.Mutex.Unlock()
+
+	if match == nil {
+		return false
+	}
+	match.ch <- eventWrapper
+	return true
+}
+
+// Passes the event to the first waiter it matches, and marks it as waited, so the OnXxx
+// handlers skip it. Returns true if the event needs no queueing afterwards: a waiter took it,
+// it is not a START or STOP event, and nobody subscribed to it.
+func (wfInst *workflowInstance) takeForWaiter(eventWrapper *EventWrapper) bool {
+	if eventWrapper.waited || !wfInst.deliverToWaiter(*eventWrapper) {
+		return false
+	}
+	if lifecycleEvent(eventWrapper.EventName) {
+		// the handlers always see START and STOP
+		return false
+	}
+	eventWrapper.waited = true
+	return !wfInst.Subscriptions.has(eventWrapper.EventName)
+}
+
+// Passes an event the event queue is about to queue, or is holding back, to a waiter that
+// started waiting since it was read. START and STOP events stay in the queue for the handlers.
+func (wfInst *workflowInstance) divertToWaiter(eventWrapper *EventWrapper) bool {
+	if lifecycleEvent(eventWrapper.EventName) {
+		return false
+	}
+	return wfInst.takeForWaiter(eventWrapper)
+}
+
+// must be called with the mutex held
+func (wfInst *workflowInstance) removeWaiter(waiter *eventWaiter) {
+	for i, w := range wfInst.Waiters {
+		if w == waiter {
+			wfInst.Waiters = append(wfInst.Waiters[:i:i], wfInst.Waiters[i+1:]...)
+			return
+		}
+	}
+}
+
+func (waiter *eventWaiter) matches(eventWrapper EventWrapper) bool {
+	if eventWrapper.EventName != waiter.event {
+		return false
+	}
+	for _, filter := range waiter.filters {
+		if !filter(eventWrapper) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,111 @@
+// Copyright © 2022 Relay Inc.
+
+package sdk_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"relay-go/pkg/relaytest"
+	"relay-go/pkg/sdk"
+)
+
+// An event taken by WaitForEvent skips the OnXxx handler, but still reaches the subscribers.
+func TestWaitedEventReachesSubscribers(t *testing.T) {
+	server := relaytest.NewServer()
+	defer server.Close()
+	got := make(chan string, 10)
+	waiting := make(chan struct{})
+	server.AddWorkflow("wait", func(api sdk.RelayApi) {
+		api.OnButton(func(buttonEvent sdk.ButtonEvent) {
+			got <- "handler " + buttonEvent.Taps
+		})
+		sdk.SubscribeEvent(api, sdk.BUTTON, func(buttonEvent sdk.ButtonEvent) {
+			got <- "subscriber " + buttonEvent.Taps
+		})
+		api.OnStart(func(startEvent sdk.StartEvent) {
+			close(waiting)
+			buttonEvent, err := api.WaitForButton(api.Context(), deviceA, "double")
+			if err != nil {
+				t.Error(err)
+			}
+			got <- "waiter " + buttonEvent.Taps
+		})
+	})
+	session, err := server.Dial("wait")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer session.Close()
+	session.SendStart(deviceA)
+	<-waiting
+	// give the handler time to start waiting
+	time.Sleep(50 * time.Millisecond)
+	session.SendButton(deviceA, "action", "double")
+	session.SendButton(deviceA, "action", "single")
+
+	for _, want := range []string{"waiter double", "subscriber double", "handler single", "subscriber single"} {
+		select {
+		case event := <-got:
+			if event != want {
+				t.Fatalf("got %q, want %q", event, want)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatalf("timed out waiting for %q", want)
+		}
+	}
+}
+
+// A button pressed during SayAndWait is queued, and taken by a WaitForButton that starts
+// after the prompt, instead of reaching the OnButton handler.
+func TestWaitTakesQueuedEvent(t *testing.T) {
+	server := relaytest.NewServer()
+	defer server.Close()
+	got := make(chan string, 10)
+	server.AddWorkflow("wait", func(api sdk.RelayApi) {
+		api.OnButton(func(buttonEvent sdk.ButtonEvent) {
+			got <- "handler " + buttonEvent.Taps
+		})
+		api.OnStart(func(startEvent sdk.StartEvent) {
+			api.SayAndWait(deviceA, "Double tap to confirm", sdk.ENGLISH)
+			ctx, cancel := context.WithTimeout(api.Context(), 5*time.Second)
+			defer cancel()
+			buttonEvent, err := api.WaitForButton(ctx, deviceA, "double")
+			if err != nil {
+				t.Error(err)
+			}
+			got <- "waiter " + buttonEvent.Taps
+		})
+	})
+	session, err := server.Dial("wait")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer session.Close()
+	session.Respond("say", func(req relaytest.Request) []map[string]interface{} {
+		frames := session.DefaultResponse(req)
+		// the button is pressed before the prompt stops
+		button := map[string]interface{}{"_type": "wf_api_button_event", "source_uri": deviceA, "button": "action", "taps": "double"}
+		return append(frames[:2:2], button, frames[2])
+	})
+	session.SendStart(deviceA)
+
+	select {
+	case event := <-got:
+		if event != "waiter double" {
+			t.Fatalf("got %q, want the waiter to take the queued button", event)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for the waiter")
+	}
+	session.SendButton(deviceA, "action", "single")
+	select {
+	case event := <-got:
+		if event != "handler single" {
+			t.Fatalf("got %q, want only the later button to reach the handler", event)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for the handler")
+	}
+}
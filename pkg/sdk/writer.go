@@ -0,0 +1,80 @@
+// Copyright © 2022 Relay Inc.
+
+package sdk
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/gorilla/websocket"
+)
+
+// A frame waiting for the writer goroutine of a workflow instance.
+type outboundFrame struct {
+	msg    interface{}
+	result chan error
+}
+
+// The writer goroutine of a workflow instance. gorilla's websocket allows only one writer
+// at a time, so every frame, except control frames, is written here in the order it was
+// queued. Runs until the websocket is gone, a write fails, which closes the websocket, or a
+// frame panics while it is marshalled, which stops the instance.
+func (wfInst *workflowInstance) writeFrames() {
+	defer wfInst.recoverPanic()
+	for {
+		select {
+		case frame := <-wfInst.Outbox:
+			data, err := json.Marshal(frame.msg)
+			if err != nil {
+				frame.result <- err
+				continue
+			}
+			wfInst.Recorder.record(RECORD_OUTBOUND, data)
+			wfInst.WebsocketConnection.SetWriteDeadline(wfInst.writeDeadline())
+			err = wfInst.WebsocketConnection.WriteMessage(websocket.TextMessage, data)
+			// the result is in before the websocket goes away
+			frame.result <- err
+			if err != nil {
+				// the frames still queued fail as the workflow stopped
+				wfInst.closeAfterWriteError(err)
+				return
+			}
+		case <-wfInst.Disconnected:
+			return
+		}
+	}
+}
+
+// A websocket is unusable once a write failed, i.e. timed out, so it is closed, which makes
+// the reader tear down the instance and, unless the instance was stopping anyway, call the
+// OnDisconnect handler with ErrConnectionLost.
+func (wfInst *workflowInstance) closeAfterWriteError(err error) {
+	wfInst.Logger.Warn("error writing to websocket, closing it: ", err)
+	if wfInst.stopReason() == "" {
+		wfInst.setDisconnectErr(fmt.Errorf("%w: %v", ErrConnectionLost, err))
+	}
+	wfInst.WebsocketConnection.Close()
+}
+
+// Queues a frame for the writer goroutine and waits until it was written. Safe for
+// concurrent use. Returns ErrWorkflowStopped if the websocket is gone.
+func (wfInst *workflowInstance) writeJSON(msg interface{}) error {
+	frame := outboundFrame{msg: msg, result: make(chan error, 1)}
+	select {
+	case wfInst.Outbox <- frame:
+	case <-wfInst.Disconnected:
+		return ErrWorkflowStopped
+	}
+	select {
+	case err := <-frame.result:
+		return err
+	case <-wfInst.Disconnected:
+		// the frame may have been written, or failed, as the websocket went away
+		select {
+		case err := <-frame.result:
+			return err
+		default:
+			return ErrWorkflowStopped
+		}
+	}
+}
@@ -0,0 +1,98 @@
+// Copyright © 2022 Relay Inc.
+
+package sdk_test
+
+import (
+	"fmt"
+	"math/rand"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"relay-go/pkg/relaytest"
+	"relay-go/pkg/sdk"
+)
+
+// Requests made from many goroutines at once must each reach the Relay server as a whole
+// frame, and each get the response to its own request, whatever order the responses come in.
+// Run with -race.
+func TestConcurrentRequests(t *testing.T) {
+	const goroutines, requests = 20, 10
+	server := relaytest.NewServer()
+	defer server.Close()
+	failures := make(chan string, goroutines*requests)
+	finished := make(chan struct{})
+	server.AddWorkflow("concurrent", func(api sdk.RelayApi) {
+		api.OnStart(func(startEvent sdk.StartEvent) {
+			var wg sync.WaitGroup
+			for g := 0; g < goroutines; g++ {
+				wg.Add(1)
+				go func(g int) {
+					defer wg.Done()
+					for i := 0; i < requests; i++ {
+						// longer than the write buffer, so each frame is written in pieces
+						name := fmt.Sprintf("var-%d-%d-%s", g, i, strings.Repeat("x", 2000))
+						value, err := api.GetVarCtx(api.Context(), name, "")
+						if err != nil {
+							failures <- fmt.Sprintf("%.12s: %v", name, err)
+						} else if value != "value of "+name {
+							failures <- fmt.Sprintf("%.12s: got the response %.21s", name, value)
+						}
+					}
+				}(g)
+			}
+			wg.Wait()
+			close(finished)
+		})
+	})
+	session, err := server.Dial("concurrent")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer session.Close()
+	// answer out of order
+	session.Respond("get_var", func(req relaytest.Request) []map[string]interface{} {
+		go func() {
+			time.Sleep(time.Duration(rand.Intn(5)) * time.Millisecond)
+			session.Send(map[string]interface{}{
+				"_type": "wf_api_get_var_response",
+				"_id":   req.Id,
+				"value": "value of " + req.String("name"),
+			})
+		}()
+		return nil
+	})
+	session.SendStart(deviceA)
+
+	select {
+	case <-finished:
+	case <-time.After(10 * time.Second):
+		t.Fatal("the requests did not complete")
+	}
+	close(failures)
+	for failure := range failures {
+		t.Error(failure)
+	}
+
+	reqs := session.Requests()
+	if len(reqs) != goroutines*requests {
+		t.Fatalf("the server got %d requests, want %d", len(reqs), goroutines*requests)
+	}
+	ids := make(map[string]bool)
+	names := make(map[string]bool)
+	for _, req := range reqs {
+		name := req.String("name")
+		if req.Type != "get_var" || req.Id == "" || len(req.Fields) != 3 || !strings.HasSuffix(name, strings.Repeat("x", 2000)) {
+			t.Errorf("got a mangled request %.80s", req.Frame)
+		}
+		if ids[req.Id] {
+			t.Errorf("the _id %s was sent twice", req.Id)
+		}
+		ids[req.Id] = true
+		names[name] = true
+	}
+	if len(names) != goroutines*requests {
+		t.Errorf("the server got %d distinct requests, want %d", len(names), goroutines*requests)
+	}
+}
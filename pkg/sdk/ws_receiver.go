@@ -3,76 +3,141 @@
 package sdk
 
 import (
-    log "github.com/sirupsen/logrus"
+    "fmt"
 )
 
 func (wfInst *workflowInstance) receiveWs() {
+    defer close(wfInst.Disconnected)
+    defer wfInst.Cancel()
     defer wfInst.WebsocketConnection.Close()
+    defer wfInst.recoverPanic()
+    wfInst.startKeepalive()
 
     var err error 
+    stopped := false
     for err == nil {
         // Read message from websocket connection
         _, msg, err := wfInst.WebsocketConnection.ReadMessage()
         if err != nil {
-            if wfInst.StopReason == "normal" {
+            stopReason := wfInst.stopReason()
+            if stopReason == "normal" {
                 // eat it
                 return
-            } else if wfInst.StopReason != "" {
-                log.Info("websocket closed with reason: ", wfInst.StopReason)
+            } else if stopReason != "" {
+                wfInst.Logger.Info("websocket closed with reason: ", stopReason)
+                return
+            } else if !stopped {
+                // the Relay server went away without stopping the workflow, or stopped answering
+                wfInst.setDisconnectErr(fmt.Errorf("%w: %v", ErrConnectionLost, err))
                 return
             } else {
-                log.Debug("Error reading message from websocket: ", err, msg)
+                wfInst.Logger.Debug("Error reading message from websocket: ", err, msg)
                 return
             }
         }
+        wfInst.extendReadDeadline()
+        wfInst.Recorder.record(RECORD_INBOUND, msg)
         
         // messages are either events or responses to requests we sent
-        parsedMsg, eventName, messageType := parseMessage(msg)
+        parsedMsg, eventName, messageType, err := parseMessage(msg)
+        if err != nil {
+            // skip the frame, the next one may be fine
+            wfInst.Logger.Warn("ignoring frame from relay server: ", err)
+            continue
+        }
         eventWrapper := EventWrapper{ParsedMsg: parsedMsg, Msg: msg, EventName: eventName}
+        if eventName == STOP {
+            stopped = true
+            // unblock pending requests right away, the workflow may be busy in one of them
+            wfInst.Cancel()
+        }
+        
+        if eventName == ERROR {
+            // an error response completes the call it answers, and is also delivered to the
+            // OnError handler as an event
+            wfInst.handleErrorResponse(eventWrapper)
+            messageType = EVENT
+        }
         
         // including speech event so that it can be passed to handleResponse for a listen API call
         if messageType == RESPONSE || eventName == SPEECH {
             // pair with callback
-            err = wfInst.handleResponse(EventWrapper{ParsedMsg: parsedMsg, Msg: msg, EventName: eventName})
-            if err != nil {
-                log.Debug("Error from response handler ", err)
-                return
+            if wfInst.handleResponse(eventWrapper) || messageType == RESPONSE {
+                continue
             }
-        } else if messageType == EVENT {
-            // send events to event channel
-            select {
-                case wfInst.EventChannel <- eventWrapper:
-                    if eventName == PROMPT && eventWrapper.ParsedMsg["type"].(string) == "stopped" {
-                        streamingComplete = true
-                    }
-
-                default:
-                    log.Debug("Error, can't send to event channel")
-                    return
+            // no listen is waiting for the speech, i.e. it timed out, pass it to OnSpeech
+            messageType = EVENT
+        }
+        if messageType == EVENT {
+            if eventName == PROMPT && eventWrapper.ParsedMsg["type"] == "stopped" {
+                wfInst.promptStopped(eventWrapper)
+            }
+            // a goroutine blocked in WaitForEvent takes the event, it is still queued for the
+            // subscribers, and START and STOP also for the handlers
+            if wfInst.takeForWaiter(&eventWrapper) {
+                continue
+            }
+            // queue events for the workflow, this blocks if the queue is full and its policy
+            // is to apply backpressure
+            if wfInst.EventQueue.push(eventWrapper) {
+                wfInst.Logger.Warn("event queue full, dropped an event")
             }
         } 
     }
-    log.Debug("error received from websocket", err, "quitting")
+    wfInst.Logger.Debug("error received from websocket", err, "quitting")
 }
 
-func (wfInst *workflowInstance) handleResponse(eventWrapper EventWrapper) error {
-    log.Debug("handling response for ", eventWrapper.ParsedMsg)
+// completes the pending call the response answers. Returns false if no call is waiting for it.
+func (wfInst *workflowInstance) handleResponse(eventWrapper EventWrapper) bool {
+    wfInst.Logger.Debug("handling response for ", eventWrapper.ParsedMsg)
     // find the matching request and complete the call. If the type is a speech event, it will contain a "request_id" instead of "_id".  This
-    // request_id will correspond to the listen request id, if a listen was called.
+    // request_id will correspond to the listen request id, if a listen was called. The listen response only acknowledges the listen
+    // request, the call is completed by the speech event.
     var id string
-    if (eventWrapper.ParsedMsg["_type"].(string) == "wf_api_speech_event") {
-        id = eventWrapper.ParsedMsg["request_id"].(string)
+    msgType, _ := eventWrapper.ParsedMsg["_type"].(string)
+    if (msgType == "wf_api_speech_event") {
+        id, _ = eventWrapper.ParsedMsg["request_id"].(string)
     } else {
-        id = eventWrapper.ParsedMsg["_id"].(string)
+        id, _ = eventWrapper.ParsedMsg["_id"].(string)
     }
-    if (eventWrapper.ParsedMsg["_type"].(string) != "wf_api_listen_response") {
+    if (msgType != "wf_api_listen_response") {
         wfInst.Mutex.Lock()
-        call := wfInst.Pending[id]
+        call, ok := wfInst.Pending[id]
+        if !ok {
+            // the request timed out or was cancelled, or this is a duplicate response
+            wfInst.Mutex.Unlock()
+            wfInst.Logger.Debug("no pending request for ", msgType, " with id ", id)
+            return false
+        }
         delete(wfInst.Pending, id)
+        if call.WaitForPrompt {
+            // track the prompt before completing the call, its stopped event may be the next message
+            if promptId, _ := eventWrapper.ParsedMsg["id"].(string); promptId != "" {
+                call.PromptId = promptId
+                call.PromptStopped = make(chan struct{})
+                wfInst.Prompts[promptId] = call.PromptStopped
+            }
+        }
         wfInst.Mutex.Unlock()
         call.EventWrapper = eventWrapper
         call.Res = eventWrapper.ParsedMsg
         call.Done <- true
     }
-    return nil
+    return true
+}
+
+func (wfInst *workflowInstance) handleErrorResponse(eventWrapper EventWrapper) {
+    wfInst.Logger.Debug("handling error response ", eventWrapper.ParsedMsg)
+    id, _ := eventWrapper.ParsedMsg["_id"].(string)
+    wfInst.Mutex.Lock()
+    call, ok := wfInst.Pending[id]
+    delete(wfInst.Pending, id)
+    wfInst.Mutex.Unlock()
+    if !ok {
+        wfInst.Logger.Debug("no pending request for error response with id ", id)
+        return
+    }
+    call.EventWrapper = eventWrapper
+    call.Res = eventWrapper.ParsedMsg
+    call.Done <- true
 }
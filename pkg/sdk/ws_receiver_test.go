@@ -0,0 +1,140 @@
+// Copyright © 2022 Relay Inc.
+
+package sdk
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+)
+
+// frames from the Relay server, and broken ones
+var frameSeeds = []string{
+	`{"_type":"wf_api_button_event","source_uri":"urn:relay-resource:name:device:alice","button":"action","taps":"single"}`,
+	`{"_type":"wf_api_stop_event","reason":"normal"}`,
+	`{"_type":"wf_api_say_response","_id":"1","id":"prompt-1"}`,
+	`{"_type":"wf_api_listen_response","_id":"1"}`,
+	`{"_type":"wf_api_speech_event","request_id":"1","text":"hello","lang":"en-US"}`,
+	`{"_type":"wf_api_error_response","_id":"1","code":"invalid_target","message":"target urn:relay-resource:name:device:nobody is not a device","request_type":"wf_api_say_request"}`,
+	`{"_type":"wf_api_error_response","_id":"1","code":"timeout"}`,
+	`{"_type":"wf_api_error_response","_id":"1","code":404,"message":"not found"}`,
+	`{"_type":"wf_api_say_resp`,
+	`[1,2,3]`,
+	`"wf_api_say_response"`,
+	`null`,
+	`{"_id":"1"}`,
+	`{"_type":5,"_id":"1"}`,
+	`{"_type":"wf_api_say_response"}`,
+	`{"_type":"wf_api_say_response","_id":1}`,
+	`{"_type":"wf_api_speech_event","text":"hello"}`,
+	`{"_type":"wf_api_say_request","_id":"1"}`,
+}
+
+func FuzzParseMessage(f *testing.F) {
+	for _, seed := range frameSeeds {
+		f.Add([]byte(seed))
+	}
+	f.Fuzz(func(t *testing.T, msg []byte) {
+		parsedMsg, eventName, messageType, err := parseMessage(msg)
+		if err != nil {
+			var frameError *FrameError
+			if !errors.As(err, &frameError) || parsedMsg != nil {
+				t.Fatalf("parseMessage(%q) = %v, %v; want a *FrameError and no message", msg, parsedMsg, err)
+			}
+			return
+		}
+		if messageType != EVENT && messageType != RESPONSE {
+			t.Fatalf("parseMessage(%q) returned message type %q", msg, messageType)
+		}
+		if want := "wf_api_" + string(eventName) + "_" + messageType; parsedMsg["_type"] != want {
+			t.Fatalf("parseMessage(%q) returned %s %s for _type %v", msg, eventName, messageType, parsedMsg["_type"])
+		}
+	})
+}
+
+func FuzzHandleResponse(f *testing.F) {
+	for _, seed := range frameSeeds {
+		f.Add([]byte(seed), false)
+		f.Add([]byte(seed), true)
+	}
+	f.Fuzz(func(t *testing.T, msg []byte, waitForPrompt bool) {
+		parsedMsg, eventName, _, err := parseMessage(msg)
+		if err != nil {
+			return
+		}
+		wfInst := newTestInstance()
+		defer wfInst.Cancel()
+		call := &Call{Done: make(chan bool, 100), WaitForPrompt: waitForPrompt}
+		wfInst.Pending["1"] = call
+		eventWrapper := EventWrapper{ParsedMsg: parsedMsg, Msg: msg, EventName: eventName}
+
+		if eventName == ERROR {
+			wfInst.handleErrorResponse(eventWrapper)
+		} else {
+			wfInst.handleResponse(eventWrapper)
+		}
+		_, pending := wfInst.Pending["1"]
+		if completed := len(call.Done) > 0; completed == pending {
+			t.Fatalf("frame %q: call completed %v, still pending %v", msg, completed, pending)
+		}
+		if len(call.Done) > 1 {
+			t.Fatalf("frame %q completed the call %d times", msg, len(call.Done))
+		}
+	})
+}
+
+func responseFrame(t *testing.T, fields map[string]interface{}) EventWrapper {
+	t.Helper()
+	msg, err := json.Marshal(fields)
+	if err != nil {
+		t.Fatal(err)
+	}
+	parsedMsg, eventName, _, err := parseMessage(msg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return EventWrapper{ParsedMsg: parsedMsg, Msg: msg, EventName: eventName}
+}
+
+func TestLateResponseIsIgnored(t *testing.T) {
+	wfInst := newTestInstance()
+	defer wfInst.Cancel()
+	call := &Call{Done: make(chan bool, 100)}
+	wfInst.Pending["1"] = call
+	if wfInst.awaitCall(context.Background(), call, "1", time.Millisecond) {
+		t.Fatal("awaitCall returned true without a response")
+	}
+	if call.Error != ErrRequestTimeout {
+		t.Fatalf("call error is %v, want ErrRequestTimeout", call.Error)
+	}
+
+	if wfInst.handleResponse(responseFrame(t, map[string]interface{}{"_type": "wf_api_say_response", "_id": "1"})) {
+		t.Error("a response after the timeout completed the call")
+	}
+	wfInst.handleErrorResponse(responseFrame(t, map[string]interface{}{"_type": "wf_api_error_response", "_id": "1", "code": "timeout", "message": "request timed out", "request_type": "wf_api_say_request"}))
+	if len(call.Done) != 0 || call.Error != ErrRequestTimeout {
+		t.Errorf("late responses changed the timed out call: %d completions, error %v", len(call.Done), call.Error)
+	}
+}
+
+func TestDuplicateResponseIsIgnored(t *testing.T) {
+	wfInst := newTestInstance()
+	defer wfInst.Cancel()
+	call := &Call{Done: make(chan bool, 100)}
+	wfInst.Pending["1"] = call
+	response := responseFrame(t, map[string]interface{}{"_type": "wf_api_say_response", "_id": "1", "id": "prompt-1"})
+	if !wfInst.handleResponse(response) {
+		t.Fatal("the response did not complete the call")
+	}
+	if !wfInst.awaitCall(context.Background(), call, "1", time.Second) {
+		t.Fatal("awaitCall did not return the response")
+	}
+	if wfInst.handleResponse(response) {
+		t.Error("the duplicate response completed a call")
+	}
+	if len(call.Done) != 0 {
+		t.Errorf("the duplicate response completed the call again")
+	}
+}
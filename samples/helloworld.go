@@ -13,32 +13,35 @@ var port = ":8080"
 func main() {
     log.SetLevel(log.InfoLevel)
 
-    sdk.AddWorkflow("hellopath", func(api sdk.RelayApi) {
-        
-        api.OnStart(func(startEvent sdk.StartEvent) {
-            sourceUri := api.GetSourceUri(startEvent)
-            log.Debug("Started hello wf from sourceUri: ", sourceUri, " trigger: ", startEvent.Trigger)
-            api.StartInteraction(sourceUri, "hello interaction")
-        })
-        
-        api.OnInteractionLifecycle(func(interactionLifecycleEvent sdk.InteractionLifecycleEvent) {
-            log.Debug("User workflow got interaction lifecycle: ", interactionLifecycleEvent)
-
-            if interactionLifecycleEvent.LifecycleType == "started" {
-                interactionUri := interactionLifecycleEvent.SourceUri
-                var deviceName = api.GetDeviceName(interactionUri, false)
-                api.SayAndWait(interactionUri, "What is your name?", sdk.ENGLISH)
-                var name = api.Listen(interactionUri, []string {}, false, sdk.ENGLISH, 30)
-                api.Say(interactionUri, "Hello " + name + " you are currently using " + deviceName, sdk.ENGLISH)
-                api.EndInteraction(interactionUri)
-            }
-
-            if interactionLifecycleEvent.LifecycleType == "ended" {
-                log.Debug("i'm a callback for interaction lifecycle: ", interactionLifecycleEvent)
-                api.Terminate()
-            }
-        })
+    server := sdk.NewServer(sdk.WithAddr(port))
+
+    server.AddWorkflow("hellopath", helloWorkflow)
+    
+    log.Fatal(server.ListenAndServe())
+}
+
+func helloWorkflow(api sdk.RelayApi) {
+    api.OnStart(func(startEvent sdk.StartEvent) {
+        sourceUri := api.GetSourceUri(startEvent)
+        log.Debug("Started hello wf from sourceUri: ", sourceUri, " trigger: ", startEvent.Trigger)
+        api.StartInteraction(sourceUri, "hello interaction")
     })
     
-    sdk.InitializeRelaySdk(port)
-}
\ No newline at end of file
+    api.OnInteractionLifecycle(func(interactionLifecycleEvent sdk.InteractionLifecycleEvent) {
+        log.Debug("User workflow got interaction lifecycle: ", interactionLifecycleEvent)
+
+        if interactionLifecycleEvent.LifecycleType == "started" {
+            interactionUri := interactionLifecycleEvent.SourceUri
+            var deviceName = api.GetDeviceName(interactionUri, false)
+            api.SayAndWait(interactionUri, "What is your name?", sdk.ENGLISH)
+            var name = api.Listen(interactionUri, []string {}, false, sdk.ENGLISH, 30)
+            api.Say(interactionUri, "Hello " + name + " you are currently using " + deviceName, sdk.ENGLISH)
+            api.EndInteraction(interactionUri)
+        }
+
+        if interactionLifecycleEvent.LifecycleType == "ended" {
+            log.Debug("i'm a callback for interaction lifecycle: ", interactionLifecycleEvent)
+            api.Terminate()
+        }
+    })
+}
@@ -0,0 +1,62 @@
+// Copyright © 2022 Relay Inc.
+
+package main
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"relay-go/pkg/relaytest"
+)
+
+const (
+	deviceUri      = "urn:relay-resource:name:device:alice"
+	interactionUri = "urn:relay-resource:name:interaction:hello%20interaction?device=urn%3Arelay-resource%3Aname%3Adevice%3Aalice"
+)
+
+// Drives the hello world workflow through a whole conversation against relaytest.
+func TestHelloWorld(t *testing.T) {
+	server := relaytest.NewServer()
+	defer server.Close()
+	server.AddWorkflow("hellopath", helloWorkflow)
+	session, err := server.Dial("hellopath")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer session.Close()
+	session.RespondWith("get_device_info", map[string]interface{}{"name": "Alice's Relay"})
+	session.QueueSpeech("Bob")
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	session.SendStart(deviceUri)
+	if _, err := session.WaitForRequest(ctx, "start_interaction"); err != nil {
+		t.Fatal(err)
+	}
+	session.SendLifecycle(interactionUri, "started")
+	if _, err := session.WaitForRequest(ctx, "end_interaction"); err != nil {
+		t.Fatal(err)
+	}
+	session.SendLifecycle(interactionUri, "ended")
+	select {
+	case <-session.Done():
+	case <-ctx.Done():
+		t.Fatal("the workflow did not terminate")
+	}
+
+	want := []string{"start_interaction", "get_device_info", "say", "listen", "say", "end_interaction", "terminate"}
+	if got := session.RequestTypes(); !reflect.DeepEqual(got, want) {
+		t.Fatalf("the workflow sent %v, want %v", got, want)
+	}
+	requests := session.Requests()
+	if text := requests[4].String("text"); text != "Hello Bob you are currently using Alice's Relay" {
+		t.Errorf("the workflow said %q", text)
+	}
+	for _, req := range requests[1:6] {
+		if len(req.Target) != 1 || req.Target[0] != interactionUri {
+			t.Errorf("the %s request was sent to %v, want the interaction", req.Type, req.Target)
+		}
+	}
+}